@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/ledufranco/recruitment-system/internal/config"
 	"github.com/ledufranco/recruitment-system/internal/database"
 	"github.com/ledufranco/recruitment-system/internal/models"
@@ -27,7 +28,7 @@ func main() {
 	}
 
 	log.Println("Creating users...")
-	
+
 	adminPassword, _ := utils.HashPassword("admin123")
 	candidatePassword, _ := utils.HashPassword("candidate123")
 
@@ -69,10 +70,10 @@ func main() {
 
 	log.Println("Creating job listings...")
 
-	salaryFrontend := 8000.0
-	salaryBackend := 9000.0
+	salaryFrontendMin, salaryFrontendMax := 8000.0, 12000.0
+	salaryBackendMin, salaryBackendMax := 9000.0, 13000.0
 	salaryFullstack := 10000.0
-	salaryDevOps := 11000.0
+	salaryDevOpsMin, salaryDevOpsMax := 11000.0, 15000.0
 	salaryMobile := 8500.0
 
 	jobs := []models.Job{
@@ -80,7 +81,8 @@ func main() {
 			RecruiterID: admin.ID,
 			Title:       "Desenvolvedor Frontend React",
 			Description: "Estamos buscando um desenvolvedor Frontend experiente com React, TypeScript e Tailwind CSS. Você irá trabalhar em projetos desafiadores construindo interfaces modernas e responsivas.\n\nRequisitos:\n• 2+ anos de experiência com React\n• TypeScript\n• HTML5, CSS3\n• Git\n• API REST\n\nDiferenciais:\n• Next.js\n• Testes automatizados\n• UI/UX design",
-			Salary:      &salaryFrontend,
+			SalaryMin:   &salaryFrontendMin,
+			SalaryMax:   &salaryFrontendMax,
 			Location:    "São Paulo, SP",
 			Type:        models.JobTypeRemote,
 			Status:      models.JobStatusOpen,
@@ -89,7 +91,8 @@ func main() {
 			RecruiterID: admin.ID,
 			Title:       "Desenvolvedor Backend Go",
 			Description: "Procuramos desenvolvedor Backend com experiência em Go para trabalhar em sistemas de alta performance e escalabilidade.\n\nRequisitos:\n• 3+ anos de experiência com Go\n• APIs RESTful\n• PostgreSQL ou MySQL\n• Docker\n• Microserviços\n\nDiferenciais:\n• Kubernetes\n• Redis\n• RabbitMQ ou Kafka\n• Clean Architecture",
-			Salary:      &salaryBackend,
+			SalaryMin:   &salaryBackendMin,
+			SalaryMax:   &salaryBackendMax,
 			Location:    "Rio de Janeiro, RJ",
 			Type:        models.JobTypeHybrid,
 			Status:      models.JobStatusOpen,
@@ -98,7 +101,8 @@ func main() {
 			RecruiterID: admin.ID,
 			Title:       "Desenvolvedor Full Stack",
 			Description: "Buscamos desenvolvedor Full Stack para atuar em projetos completos, do backend ao frontend.\n\nRequisitos:\n• React ou Vue.js\n• Node.js ou Go\n• Bancos de dados SQL\n• Git e metodologias ágeis\n\nO que oferecemos:\n• Ambiente colaborativo\n• Projetos desafiadores\n• Horários flexíveis\n• Vale alimentação e refeição",
-			Salary:      &salaryFullstack,
+			SalaryMin:   &salaryFullstack,
+			SalaryMax:   &salaryFullstack,
 			Location:    "Belo Horizonte, MG",
 			Type:        models.JobTypeRemote,
 			Status:      models.JobStatusOpen,
@@ -107,7 +111,8 @@ func main() {
 			RecruiterID: admin.ID,
 			Title:       "DevOps Engineer",
 			Description: "Estamos em busca de um DevOps Engineer para melhorar nossa infraestrutura e processos de deploy.\n\nRequisitos:\n• Experiência com AWS, GCP ou Azure\n• Kubernetes\n• Docker\n• CI/CD (Jenkins, GitLab CI, GitHub Actions)\n• Terraform ou Ansible\n• Monitoramento (Prometheus, Grafana)\n\nDiferenciais:\n• Certificações Cloud\n• Experiência com ambientes de produção\n• Shell scripting",
-			Salary:      &salaryDevOps,
+			SalaryMin:   &salaryDevOpsMin,
+			SalaryMax:   &salaryDevOpsMax,
 			Location:    "São Paulo, SP",
 			Type:        models.JobTypeOnsite,
 			Status:      models.JobStatusOpen,
@@ -116,7 +121,8 @@ func main() {
 			RecruiterID: admin.ID,
 			Title:       "Desenvolvedor Mobile React Native",
 			Description: "Desenvolvedor Mobile para criar aplicativos incríveis para iOS e Android usando React Native.\n\nRequisitos:\n• 2+ anos com React Native\n• JavaScript/TypeScript\n• Integração com APIs\n• Publicação nas stores (App Store e Play Store)\n\nDiferenciais:\n• Expo\n• Redux ou Context API\n• Firebase\n• Push notifications",
-			Salary:      &salaryMobile,
+			SalaryMin:   &salaryMobile,
+			SalaryMax:   &salaryMobile,
 			Location:    "Curitiba, PR",
 			Type:        models.JobTypeRemote,
 			Status:      models.JobStatusOpen,
@@ -139,14 +145,69 @@ func main() {
 		},
 	}
 
-	for _, job := range jobs {
-		if err := db.Create(&job).Error; err != nil {
-			log.Printf("Job '%s' may already exist: %v", job.Title, err)
+	for i := range jobs {
+		if err := db.Create(&jobs[i]).Error; err != nil {
+			log.Printf("Job '%s' may already exist: %v", jobs[i].Title, err)
 		} else {
-			log.Printf("✓ Job created: %s", job.Title)
+			log.Printf("✓ Job created: %s", jobs[i].Title)
 		}
 	}
 
+	log.Println("Creating skills...")
+
+	skills := map[string]*models.Skill{}
+	for name, aliases := range map[string][]string{
+		"Go":           {"golang"},
+		"React":        {"react.js", "reactjs"},
+		"React Native": {"rn"},
+		"TypeScript":   {"ts"},
+		"JavaScript":   {"js"},
+		"PostgreSQL":   {"postgres", "psql"},
+		"Docker":       nil,
+		"Kubernetes":   {"k8s"},
+		"Node.js":      {"node", "nodejs"},
+		"AWS":          {"amazon web services"},
+		"Terraform":    nil,
+		"Git":          nil,
+		"HTML":         {"html5"},
+		"CSS":          {"css3"},
+		"Liderança":    {"lideranca", "leadership"},
+	} {
+		skill := &models.Skill{Name: name}
+		for _, alias := range aliases {
+			skill.Aliases = append(skill.Aliases, models.SkillAlias{Alias: alias})
+		}
+		if err := db.Create(skill).Error; err != nil {
+			log.Printf("Skill '%s' may already exist: %v", name, err)
+			continue
+		}
+		skills[name] = skill
+	}
+
+	jobSkills := map[string]map[string]models.SkillLevel{
+		"Desenvolvedor Frontend React":      {"React": models.SkillLevelRequired, "TypeScript": models.SkillLevelRequired, "HTML": models.SkillLevelRequired, "CSS": models.SkillLevelRequired, "Git": models.SkillLevelRequired},
+		"Desenvolvedor Backend Go":          {"Go": models.SkillLevelRequired, "PostgreSQL": models.SkillLevelRequired, "Docker": models.SkillLevelRequired, "Kubernetes": models.SkillLevelNiceToHave},
+		"Desenvolvedor Full Stack":          {"React": models.SkillLevelRequired, "Node.js": models.SkillLevelNiceToHave, "Go": models.SkillLevelNiceToHave, "Git": models.SkillLevelRequired},
+		"DevOps Engineer":                   {"AWS": models.SkillLevelRequired, "Kubernetes": models.SkillLevelRequired, "Docker": models.SkillLevelRequired, "Terraform": models.SkillLevelRequired},
+		"Desenvolvedor Mobile React Native": {"React Native": models.SkillLevelRequired, "TypeScript": models.SkillLevelRequired, "JavaScript": models.SkillLevelRequired},
+		"Tech Lead - Desenvolvimento":       {"Liderança": models.SkillLevelRequired},
+		"Estágio em Desenvolvimento Web":    {"HTML": models.SkillLevelRequired, "CSS": models.SkillLevelRequired, "JavaScript": models.SkillLevelRequired, "Git": models.SkillLevelNiceToHave},
+	}
+
+	for _, job := range jobs {
+		for name, level := range jobSkills[job.Title] {
+			skill, ok := skills[name]
+			if !ok || job.ID == uuid.Nil {
+				continue
+			}
+			link := models.JobSkill{JobID: job.ID, SkillID: skill.ID, Level: level}
+			if err := db.Omit("Skill").Create(&link).Error; err != nil {
+				log.Printf("Skill '%s' for job '%s' may already exist: %v", name, job.Title, err)
+			}
+		}
+	}
+	log.Printf("✓ Skills created: %d", len(skills))
+
 	log.Println("\n🎉 Database seed completed successfully!")
 	log.Println("\nLogin credentials:")
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -160,4 +221,3 @@ func main() {
 	log.Println("  Senha: candidate123")
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 }
-
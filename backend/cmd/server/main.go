@@ -1,21 +1,38 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/compliance"
 	"github.com/ledufranco/recruitment-system/internal/config"
 	"github.com/ledufranco/recruitment-system/internal/database"
 	"github.com/ledufranco/recruitment-system/internal/handlers"
+	"github.com/ledufranco/recruitment-system/internal/lint"
+	"github.com/ledufranco/recruitment-system/internal/mailer"
 	"github.com/ledufranco/recruitment-system/internal/middleware"
 	"github.com/ledufranco/recruitment-system/internal/models"
 	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/internal/scheduler"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "github.com/ledufranco/recruitment-system/docs"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish once the
+// server is asked to stop.
+const shutdownTimeout = 15 * time.Second
+
 // @title           Recruitment System API
 // @version         1.0
 // @description     API para sistema de recrutamento e vagas de emprego
@@ -41,6 +58,12 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	if cfg.Jobs.MunicipalitiesFile != "" {
+		if err := geo.LoadFile(cfg.Jobs.MunicipalitiesFile); err != nil {
+			log.Fatalf("Failed to load municipalities: %v", err)
+		}
+	}
+
 	db, err := database.Connect(&cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -53,35 +76,125 @@ func main() {
 	userRepo := repository.NewUserRepository(db)
 	jobRepo := repository.NewJobRepository(db)
 	applicationRepo := repository.NewApplicationRepository(db)
+	jobReviewRepo := repository.NewJobReviewRepository(db)
+	jobTemplateRepo := repository.NewJobTemplateRepository(db)
+	jobQuestionRepo := repository.NewJobQuestionRepository(db)
+	knockoutRuleRepo := repository.NewKnockoutRuleRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	jobTeamRepo := repository.NewJobTeamRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	bookmarkRepo := repository.NewBookmarkRepository(db)
+	jobViewRepo := repository.NewJobViewRepository(db)
+	jobTranslationRepo := repository.NewJobTranslationRepository(db)
+	employerProfileRepo := repository.NewEmployerProfileRepository(db)
+
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to configure mailer: %v", err)
+	}
+
+	compliancePolicy, err := compliance.Load(cfg.Jobs.CompliancePolicyFile)
+	if err != nil {
+		log.Fatalf("Failed to load compliance policy: %v", err)
+	}
+
+	linter, err := lint.Load(cfg.Jobs.LintWordLists...)
+	if err != nil {
+		log.Fatalf("Failed to load inclusive-language word lists: %v", err)
+	}
+
+	jobAccess := handlers.NewJobAccess(jobTeamRepo)
+	viewTracker := analytics.NewViewTracker(jobViewRepo)
 
 	authHandler := handlers.NewAuthHandler(userRepo, cfg)
-	jobHandler := handlers.NewJobHandler(jobRepo)
-	applicationHandler := handlers.NewApplicationHandler(applicationRepo, jobRepo)
+	jobHandler := handlers.NewJobHandler(jobRepo, jobReviewRepo, jobTemplateRepo, jobQuestionRepo, knockoutRuleRepo, skillRepo, bookmarkRepo, jobTranslationRepo, userRepo, viewTracker, compliancePolicy, linter, jobAccess, cfg)
+	jobTemplateHandler := handlers.NewJobTemplateHandler(jobTemplateRepo)
+	jobTeamHandler := handlers.NewJobTeamHandler(jobRepo, jobTeamRepo, userRepo, jobAccess)
+	skillHandler := handlers.NewSkillHandler(skillRepo)
+	applicationHandler := handlers.NewApplicationHandler(applicationRepo, jobRepo, knockoutRuleRepo, userRepo, jobAccess, cfg)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchRepo)
+	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkRepo, jobRepo, cfg)
+	feedHandler := handlers.NewFeedHandler(jobRepo, cfg)
+	jobStatsHandler := handlers.NewJobStatsHandler(jobRepo, jobViewRepo, applicationRepo, jobAccess)
+	jobTranslationHandler := handlers.NewJobTranslationHandler(jobRepo, jobTranslationRepo, jobAccess)
+	employerProfileHandler := handlers.NewEmployerProfileHandler(employerProfileRepo, jobRepo, jobTranslationRepo)
+
+	// SIGINT and SIGTERM cancel ctx, which stops the scheduler and starts a
+	// graceful shutdown of the HTTP server.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sched := scheduler.New()
+	sched.Every("job-lifecycle", cfg.Scheduler.Interval, scheduler.JobLifecycle(jobRepo))
+	sched.Every("job-alerts", cfg.Scheduler.Interval, scheduler.JobAlerts(savedSearchRepo, jobRepo, skillRepo, mail, cfg.Server.BaseURL))
+	sched.Every("bookmark-closing-notices", cfg.Scheduler.Interval, scheduler.BookmarkClosingNotices(bookmarkRepo, mail, cfg.Server.BaseURL, cfg.Jobs.BookmarkClosingNotice))
+	if cfg.Jobs.TrashRetention > 0 {
+		sched.Every("job-trash-purge", cfg.Scheduler.Interval, scheduler.PurgeTrash(jobRepo, cfg.Jobs.TrashRetention))
+	}
+	sched.Every("job-views", cfg.Scheduler.ViewFlushInterval, viewTracker.Flush)
+	sched.Start(ctx)
 
 	gin.SetMode(cfg.Server.GinMode)
 	router := gin.Default()
 
-	router.Use(cors.New(cors.Config{
+	// Careers pages and feeds are meant to be embedded in other sites.
+	router.Use(middleware.PublicCORS(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:8080", "http://localhost:5173"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
-	}))
+	}), "/api/careers/", "/feeds/"))
+
+	setupRoutes(router, authHandler, jobHandler, jobTemplateHandler, jobTeamHandler, skillHandler, applicationHandler, savedSearchHandler, bookmarkHandler, feedHandler, jobStatsHandler, jobTranslationHandler, employerProfileHandler, cfg)
+
+	server := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: router,
+	}
 
-	setupRoutes(router, authHandler, jobHandler, applicationHandler, cfg)
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
 
-	log.Printf("Server starting on port %s", cfg.Server.Port)
-	if err := router.Run(":" + cfg.Server.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	<-ctx.Done()
+	stop()
+	log.Printf("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown failed: %v", err)
+	}
+
+	// Let scheduled tasks that were already running finish before exiting.
+	sched.Wait()
+
+	// Write the views buffered since the last flush; the scheduler's own
+	// flush ran with the cancelled context.
+	if err := viewTracker.Flush(context.Background()); err != nil {
+		log.Printf("Failed to flush job views: %v", err)
 	}
+	log.Printf("Server stopped")
 }
 
 func setupRoutes(
 	router *gin.Engine,
 	authHandler *handlers.AuthHandler,
 	jobHandler *handlers.JobHandler,
+	jobTemplateHandler *handlers.JobTemplateHandler,
+	jobTeamHandler *handlers.JobTeamHandler,
+	skillHandler *handlers.SkillHandler,
 	applicationHandler *handlers.ApplicationHandler,
+	savedSearchHandler *handlers.SavedSearchHandler,
+	bookmarkHandler *handlers.BookmarkHandler,
+	feedHandler *handlers.FeedHandler,
+	jobStatsHandler *handlers.JobStatsHandler,
+	jobTranslationHandler *handlers.JobTranslationHandler,
+	employerProfileHandler *handlers.EmployerProfileHandler,
 	cfg *config.Config,
 ) {
 	api := router.Group("/api")
@@ -100,9 +213,12 @@ func setupRoutes(
 	}
 
 	jobs := api.Group("/jobs")
+	jobs.Use(middleware.OptionalAuthMiddleware(cfg.JWT.Secret))
 	{
 		jobs.GET("", jobHandler.List)
 		jobs.GET("/:id", jobHandler.GetByID)
+		jobs.GET("/:id/similar", jobHandler.Similar)
+		jobs.GET("/:id/jsonld", feedHandler.JSONLD)
 	}
 
 	jobsProtected := api.Group("/jobs")
@@ -110,10 +226,70 @@ func setupRoutes(
 	jobsProtected.Use(middleware.RequireRole(models.RoleAdmin))
 	{
 		jobsProtected.POST("", jobHandler.Create)
+		jobsProtected.POST("/import", jobHandler.Import)
+		jobsProtected.POST("/lint", jobHandler.Lint)
 		jobsProtected.PUT("/:id", jobHandler.Update)
 		jobsProtected.DELETE("/:id", jobHandler.Delete)
 		jobsProtected.GET("/my-jobs", jobHandler.GetMyJobs)
+		jobsProtected.GET("/trash", jobHandler.Trash)
+		jobsProtected.POST("/:id/restore", jobHandler.Restore)
+		jobsProtected.POST("/:id/extend-deadline", jobHandler.ExtendDeadline)
 		jobsProtected.GET("/:id/applications", applicationHandler.GetJobApplications)
+		jobsProtected.POST("/:id/submit", jobHandler.Submit)
+		jobsProtected.POST("/:id/approve", jobHandler.Approve)
+		jobsProtected.POST("/:id/reject", jobHandler.Reject)
+		jobsProtected.GET("/:id/reviews", jobHandler.GetReviews)
+		jobsProtected.PUT("/:id/questions", jobHandler.SetQuestions)
+		jobsProtected.PUT("/:id/skills", jobHandler.SetSkills)
+		jobsProtected.GET("/:id/team", jobTeamHandler.List)
+		jobsProtected.POST("/:id/team", jobTeamHandler.Add)
+		jobsProtected.DELETE("/:id/team/:userId", jobTeamHandler.Remove)
+		jobsProtected.GET("/:id/knockout-rules", jobHandler.GetKnockoutRules)
+		jobsProtected.PUT("/:id/knockout-rules", jobHandler.SetKnockoutRules)
+		jobsProtected.GET("/:id/revisions", jobHandler.GetRevisions)
+		jobsProtected.GET("/:id/stats", jobStatsHandler.Stats)
+		jobsProtected.GET("/:id/sources", jobStatsHandler.Sources)
+		jobsProtected.GET("/:id/translations", jobTranslationHandler.List)
+		jobsProtected.PUT("/:id/translations/:locale", jobTranslationHandler.Set)
+		jobsProtected.DELETE("/:id/translations/:locale", jobTranslationHandler.Delete)
+		jobsProtected.GET("/:id/revisions/diff", jobHandler.DiffRevisions)
+		jobsProtected.POST("/:id/clone", jobHandler.Clone)
+		jobsProtected.POST("/from-template/:templateId", jobHandler.CreateFromTemplate)
+	}
+
+	jobsCandidate := api.Group("/jobs")
+	jobsCandidate.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	jobsCandidate.Use(middleware.RequireRole(models.RoleCandidate))
+	{
+		jobsCandidate.POST("/:id/bookmark", bookmarkHandler.Add)
+		jobsCandidate.DELETE("/:id/bookmark", bookmarkHandler.Remove)
+	}
+
+	me := api.Group("/me")
+	me.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	me.Use(middleware.RequireRole(models.RoleCandidate))
+	{
+		me.GET("/bookmarks", bookmarkHandler.List)
+	}
+
+	api.GET("/skills", skillHandler.List)
+
+	skillsProtected := api.Group("/skills")
+	skillsProtected.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	skillsProtected.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		skillsProtected.POST("", skillHandler.Create)
+	}
+
+	jobTemplates := api.Group("/job-templates")
+	jobTemplates.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	jobTemplates.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		jobTemplates.POST("", jobTemplateHandler.Create)
+		jobTemplates.GET("", jobTemplateHandler.List)
+		jobTemplates.GET("/:id", jobTemplateHandler.GetByID)
+		jobTemplates.PUT("/:id", jobTemplateHandler.Update)
+		jobTemplates.DELETE("/:id", jobTemplateHandler.Delete)
 	}
 
 	applicationsCandidate := api.Group("/applications")
@@ -131,6 +307,43 @@ func setupRoutes(
 		applicationsAdmin.PUT("/:id", applicationHandler.UpdateStatus)
 	}
 
+	employerProfile := api.Group("/employer-profile")
+	employerProfile.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	employerProfile.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		employerProfile.GET("", employerProfileHandler.Get)
+		employerProfile.PUT("", employerProfileHandler.Save)
+	}
+
+	api.GET("/careers/:slug", employerProfileHandler.Careers)
+
+	reports := api.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	reports.Use(middleware.RequireRole(models.RoleAdmin))
+	{
+		reports.GET("/sources", jobStatsHandler.SourceReport)
+	}
+
+	savedSearches := api.Group("/saved-searches")
+	savedSearches.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	savedSearches.Use(middleware.RequireRole(models.RoleCandidate))
+	{
+		savedSearches.GET("", savedSearchHandler.List)
+		savedSearches.POST("", savedSearchHandler.Create)
+		savedSearches.PUT("/:id", savedSearchHandler.Update)
+		savedSearches.DELETE("/:id", savedSearchHandler.Delete)
+	}
+
+	api.GET("/saved-searches/unsubscribe/:token", savedSearchHandler.UnsubscribePage)
+	api.POST("/saved-searches/unsubscribe/:token", savedSearchHandler.Unsubscribe)
+
+	feedRoutes := router.Group("/feeds")
+	{
+		feedRoutes.GET("/jobs.rss", feedHandler.RSS)
+		feedRoutes.GET("/jobs.atom", feedHandler.Atom)
+		feedRoutes.GET("/jobs.xml", feedHandler.JobBoard)
+	}
+
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+)
+
+const maxChannelLength = 100
+
+// Source describes where a visit to a job came from, from the query of the
+// job link and the Referer header: the ?ref= channel code, the utm_source,
+// utm_medium and utm_campaign parameters and ?referred_by=, the ID of the
+// employee who referred the candidate. The ID is only parsed; callers check
+// that it names an employee before keeping it.
+func Source(query url.Values, referer string) models.ApplicationSource {
+	source := models.ApplicationSource{
+		Channel:     clean(query.Get("ref"), maxChannelLength),
+		UTMSource:   clean(query.Get("utm_source"), maxReferrerLength),
+		UTMMedium:   clean(query.Get("utm_medium"), maxReferrerLength),
+		UTMCampaign: clean(query.Get("utm_campaign"), maxReferrerLength),
+		Referrer:    Referrer("", referer),
+	}
+	if id, err := uuid.Parse(query.Get("referred_by")); err == nil {
+		source.ReferredByID = &id
+	}
+	source.ResolveChannel()
+	return source
+}
+
+// Normalize cleans a source the way Source does and resolves its channel.
+func Normalize(source models.ApplicationSource) models.ApplicationSource {
+	source.Channel = clean(source.Channel, maxChannelLength)
+	source.UTMSource = clean(source.UTMSource, maxReferrerLength)
+	source.UTMMedium = clean(source.UTMMedium, maxReferrerLength)
+	source.UTMCampaign = clean(source.UTMCampaign, maxReferrerLength)
+	source.Referrer = clean(source.Referrer, maxReferrerLength)
+	source.ResolveChannel()
+	return source
+}
+
+// sourceCookie is the signed content of the source cookie. The job ID ties
+// the source to the job the visit was to.
+type sourceCookie struct {
+	JobID  uuid.UUID                `json:"job_id"`
+	Source models.ApplicationSource `json:"source"`
+}
+
+// EncodeSource packs the source of a visit to jobID into a cookie value
+// signed with secret, so the source recorded on an application is always
+// one the server captured for that job.
+func EncodeSource(jobID uuid.UUID, source models.ApplicationSource, secret string) string {
+	data, _ := json.Marshal(sourceCookie{JobID: jobID, Source: source})
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + signature("application-source", payload, secret)
+}
+
+// DecodeSource unpacks a cookie value written by EncodeSource with the same
+// secret. Values that cannot be read, were not signed by the server or were
+// written for another job yield a direct source.
+func DecodeSource(value string, jobID uuid.UUID, secret string) models.ApplicationSource {
+	payload, mac, ok := strings.Cut(value, ".")
+	if !ok || !validSignature("application-source", payload, mac, secret) {
+		return Normalize(models.ApplicationSource{})
+	}
+	var cookie sourceCookie
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || json.Unmarshal(data, &cookie) != nil || cookie.JobID != jobID {
+		return Normalize(models.ApplicationSource{})
+	}
+	return Normalize(cookie.Source)
+}
+
+func clean(value string, maxLength int) string {
+	value = strings.ToLower(strings.TrimSpace(strings.ToValidUTF8(value, "")))
+	return truncate(value, maxLength)
+}
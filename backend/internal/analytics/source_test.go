@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	t.Run("should prefer the explicit channel code over UTM parameters", func(t *testing.T) {
+		query := url.Values{"ref": {" LinkedIn "}, "utm_source": {"Indeed"}, "utm_campaign": {"Backend-2024"}}
+
+		source := Source(query, "https://www.google.com/search")
+
+		assert.Equal(t, "linkedin", source.Channel)
+		assert.Equal(t, "indeed", source.UTMSource)
+		assert.Equal(t, "backend-2024", source.UTMCampaign)
+		assert.Equal(t, "google.com", source.Referrer)
+	})
+
+	t.Run("should fall back to utm_source and then the referring site", func(t *testing.T) {
+		assert.Equal(t, "indeed", Source(url.Values{"utm_source": {"indeed"}}, "https://t.co/x").Channel)
+		assert.Equal(t, "t.co", Source(url.Values{}, "https://t.co/x").Channel)
+	})
+
+	t.Run("should credit employee referrals", func(t *testing.T) {
+		employeeID := uuid.New()
+
+		source := Source(url.Values{"ref": {"linkedin"}, "referred_by": {employeeID.String()}}, "")
+
+		assert.Equal(t, models.SourceChannelReferral, source.Channel)
+		assert.Equal(t, &employeeID, source.ReferredByID)
+	})
+
+	t.Run("should truncate long values without splitting characters", func(t *testing.T) {
+		source := Source(url.Values{"ref": {strings.Repeat("é", 150)}, "utm_campaign": {"verão\xff"}}, "")
+
+		assert.Equal(t, strings.Repeat("é", maxChannelLength), source.Channel)
+		assert.Equal(t, "verão", source.UTMCampaign)
+	})
+
+	t.Run("should report visits without any source as direct", func(t *testing.T) {
+		source := Source(url.Values{"referred_by": {"not-an-id"}}, "")
+
+		assert.Equal(t, models.SourceChannelDirect, source.Channel)
+		assert.True(t, source.IsDirect())
+	})
+}
+
+func TestSourceCookie(t *testing.T) {
+	const secret = "test-secret"
+	jobID := uuid.New()
+
+	t.Run("should round-trip a source through the cookie value", func(t *testing.T) {
+		employeeID := uuid.New()
+		source := Source(url.Values{"utm_source": {"indeed"}, "referred_by": {employeeID.String()}}, "")
+
+		assert.Equal(t, source, DecodeSource(EncodeSource(jobID, source, secret), jobID, secret))
+	})
+
+	t.Run("should treat unreadable cookies as direct", func(t *testing.T) {
+		source := DecodeSource("%%%", jobID, secret)
+
+		assert.True(t, source.IsDirect())
+		assert.Equal(t, models.SourceChannelDirect, source.Channel)
+	})
+
+	t.Run("should ignore sources the client wrote itself", func(t *testing.T) {
+		forged := EncodeSource(jobID, models.ApplicationSource{Channel: "linkedin"}, "guessed-secret")
+		payload, _, _ := strings.Cut(forged, ".")
+
+		assert.True(t, DecodeSource(forged, jobID, secret).IsDirect())
+		assert.True(t, DecodeSource(payload, jobID, secret).IsDirect())
+	})
+
+	t.Run("should ignore sources captured for another job", func(t *testing.T) {
+		value := EncodeSource(uuid.New(), models.ApplicationSource{Channel: "linkedin"}, secret)
+
+		assert.True(t, DecodeSource(value, jobID, secret).IsDirect())
+	})
+}
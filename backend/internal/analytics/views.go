@@ -0,0 +1,222 @@
+// Package analytics collects job views off the request path.
+package analytics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+// maxPendingViews bounds the views held in memory between flushes. Views
+// beyond it are dropped rather than slowing down job pages.
+const maxPendingViews = 10000
+
+const maxReferrerLength = 255
+
+type viewKey struct {
+	jobID     uuid.UUID
+	viewerKey string
+	day       time.Time
+}
+
+// ViewTracker buffers job views in memory, already deduplicated per job,
+// viewer and day, until Flush writes them in one batch.
+type ViewTracker struct {
+	repo *repository.JobViewRepository
+
+	mu      sync.Mutex
+	pending map[viewKey]models.JobView
+	dropped int
+}
+
+func NewViewTracker(repo *repository.JobViewRepository) *ViewTracker {
+	return &ViewTracker{
+		repo:    repo,
+		pending: make(map[viewKey]models.JobView),
+	}
+}
+
+// Track queues a view of the job by viewerKey at now, bucketed by UTC day.
+// It never blocks on the database.
+func (t *ViewTracker) Track(jobID uuid.UUID, viewerKey string, userID *uuid.UUID, referrer string, now time.Time) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	key := viewKey{jobID: jobID, viewerKey: viewerKey, day: day}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.pending[key]; ok {
+		return
+	}
+	if len(t.pending) >= maxPendingViews {
+		t.dropped++
+		return
+	}
+	t.pending[key] = models.JobView{
+		JobID:     jobID,
+		ViewerKey: viewerKey,
+		Day:       day,
+		UserID:    userID,
+		Referrer:  referrer,
+		CreatedAt: now,
+	}
+}
+
+// Flush writes the buffered views. It is meant to run as a scheduler task,
+// and once more on shutdown so buffered views are not lost. Views that fail
+// to save are put back for the next flush, except those the database
+// rejects, such as views of a job purged meanwhile, which are dropped.
+func (t *ViewTracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	pending := t.pending
+	dropped := t.dropped
+	t.pending = make(map[viewKey]models.JobView)
+	t.dropped = 0
+	t.mu.Unlock()
+
+	if dropped > 0 {
+		log.Printf("Dropped %d job view(s): buffer full", dropped)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	views := make([]models.JobView, 0, len(pending))
+	for _, view := range pending {
+		views = append(views, view)
+	}
+
+	err := t.repo.RecordViews(views)
+	if err == nil {
+		return nil
+	}
+	if !repository.IsRejected(err) {
+		t.requeue(pending)
+		return err
+	}
+
+	// A single rejected view fails the whole batch: save the views one by
+	// one so only the rejected ones are lost.
+	retry := make(map[viewKey]models.JobView)
+	var retryErr error
+	rejected := 0
+	for key, view := range pending {
+		err := t.repo.RecordViews([]models.JobView{view})
+		switch {
+		case err == nil:
+		case repository.IsRejected(err):
+			rejected++
+		default:
+			retry[key] = view
+			retryErr = err
+		}
+	}
+	if rejected > 0 {
+		log.Printf("Dropped %d job view(s) rejected by the database", rejected)
+	}
+	if len(retry) > 0 {
+		t.requeue(retry)
+		return retryErr
+	}
+	return nil
+}
+
+func (t *ViewTracker) requeue(views map[viewKey]models.JobView) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, view := range views {
+		if len(t.pending) >= maxPendingViews {
+			t.dropped++
+			continue
+		}
+		if _, ok := t.pending[key]; !ok {
+			t.pending[key] = view
+		}
+	}
+}
+
+// Pending returns how many views are waiting to be flushed.
+func (t *ViewTracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+// ViewerKey hashes identity, a user ID or an anonymous session identifier,
+// so views can be told apart without storing who the viewer was.
+func ViewerKey(identity string) string {
+	sum := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(sum[:])
+}
+
+// ViewerCookie returns the cookie value handed to an anonymous visitor: the
+// viewer key followed by its HMAC, so visitors cannot make up keys of their
+// own to inflate unique views.
+func ViewerCookie(viewerKey, secret string) string {
+	return viewerKey + "." + signature("job-viewer", viewerKey, secret)
+}
+
+// ViewerFromCookie returns the viewer key carried by a cookie issued by
+// ViewerCookie with the same secret.
+func ViewerFromCookie(cookie, secret string) (string, bool) {
+	viewerKey, mac, ok := strings.Cut(cookie, ".")
+	if !ok || len(viewerKey) != sha256.Size*2 {
+		return "", false
+	}
+	if !validSignature("job-viewer", viewerKey, mac, secret) {
+		return "", false
+	}
+	return viewerKey, true
+}
+
+// signature is the HMAC of value under secret. purpose keeps a signature
+// made for one cookie from being accepted by another.
+func signature(purpose, value, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validSignature(purpose, value, mac, secret string) bool {
+	return hmac.Equal([]byte(mac), []byte(signature(purpose, value, secret)))
+}
+
+// Referrer describes where a view came from: the campaign tag when the link
+// carried one, otherwise the host of the Referer header. Direct visits have
+// no referrer.
+func Referrer(ref, referer string) string {
+	ref = strings.ToLower(strings.TrimSpace(strings.ToValidUTF8(ref, "")))
+	if ref == "" && referer != "" {
+		if u, err := url.Parse(referer); err == nil {
+			ref = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		}
+	}
+	return truncate(strings.ToValidUTF8(ref, ""), maxReferrerLength)
+}
+
+// truncate cuts s to at most max characters, never inside one.
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	for i := range s {
+		if max == 0 {
+			return s[:i]
+		}
+		max--
+	}
+	return s
+}
@@ -0,0 +1,181 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockViewRepo(t *testing.T) (*repository.JobViewRepository, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return repository.NewJobViewRepository(db), mock
+}
+
+func expectInsertViews(mock sqlmock.Sqlmock, err error) {
+	mock.ExpectBegin()
+	insert := mock.ExpectExec(`INSERT INTO "job_views"`)
+	if err != nil {
+		insert.WillReturnError(err)
+		mock.ExpectRollback()
+		return
+	}
+	insert.WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestViewTracker_Track(t *testing.T) {
+	jobID := uuid.New()
+	morning := time.Date(2024, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should count a viewer once per job and day", func(t *testing.T) {
+		tracker := NewViewTracker(nil)
+
+		tracker.Track(jobID, "viewer-a", nil, "", morning)
+		tracker.Track(jobID, "viewer-a", nil, "linkedin", morning.Add(3*time.Hour))
+		tracker.Track(jobID, "viewer-b", nil, "", morning)
+		tracker.Track(jobID, "viewer-a", nil, "", morning.Add(24*time.Hour))
+		tracker.Track(uuid.New(), "viewer-a", nil, "", morning)
+
+		assert.Equal(t, 4, tracker.Pending())
+	})
+
+	t.Run("should bucket views by UTC day", func(t *testing.T) {
+		tracker := NewViewTracker(nil)
+		saoPaulo := time.FixedZone("BRT", -3*60*60)
+
+		// 22:00 in São Paulo on the 8th is already the 9th in UTC.
+		tracker.Track(jobID, "viewer-a", nil, "", time.Date(2024, 3, 8, 22, 0, 0, 0, saoPaulo))
+		tracker.Track(jobID, "viewer-a", nil, "", morning)
+
+		assert.Equal(t, 1, tracker.Pending())
+	})
+
+	t.Run("should not touch the database when nothing is pending", func(t *testing.T) {
+		tracker := NewViewTracker(nil)
+
+		assert.NoError(t, tracker.Flush(context.Background()))
+	})
+}
+
+func TestViewTracker_Flush(t *testing.T) {
+	jobID := uuid.New()
+	now := time.Date(2024, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should write pending views", func(t *testing.T) {
+		repo, mock := setupMockViewRepo(t)
+		tracker := NewViewTracker(repo)
+		tracker.Track(jobID, "viewer-a", nil, "", now)
+
+		expectInsertViews(mock, nil)
+
+		assert.NoError(t, tracker.Flush(context.Background()))
+		assert.Equal(t, 0, tracker.Pending())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should keep views for the next flush when the database is down", func(t *testing.T) {
+		repo, mock := setupMockViewRepo(t)
+		tracker := NewViewTracker(repo)
+		tracker.Track(jobID, "viewer-a", nil, "", now)
+
+		expectInsertViews(mock, errors.New("connection refused"))
+
+		assert.Error(t, tracker.Flush(context.Background()))
+		assert.Equal(t, 1, tracker.Pending())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should drop views the database rejects instead of retrying them", func(t *testing.T) {
+		repo, mock := setupMockViewRepo(t)
+		tracker := NewViewTracker(repo)
+		tracker.Track(jobID, "viewer-a", nil, "", now)
+
+		// The job was purged between the view and the flush.
+		fkViolation := &pgconn.PgError{Code: "23503"}
+		expectInsertViews(mock, fkViolation)
+		expectInsertViews(mock, fkViolation)
+
+		assert.NoError(t, tracker.Flush(context.Background()))
+		assert.Equal(t, 0, tracker.Pending())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReferrer(t *testing.T) {
+	t.Run("should prefer the campaign tag", func(t *testing.T) {
+		assert.Equal(t, "newsletter", Referrer(" Newsletter ", "https://www.google.com/search"))
+	})
+
+	t.Run("should fall back to the referer host", func(t *testing.T) {
+		assert.Equal(t, "linkedin.com", Referrer("", "https://www.LinkedIn.com/jobs/view/123"))
+	})
+
+	t.Run("should leave direct visits empty", func(t *testing.T) {
+		assert.Equal(t, "", Referrer("", ""))
+	})
+
+	t.Run("should truncate long tags without splitting characters", func(t *testing.T) {
+		ref := Referrer(strings.Repeat("ç", 300), "")
+
+		assert.Equal(t, strings.Repeat("ç", 255), ref)
+	})
+
+	t.Run("should drop invalid UTF-8", func(t *testing.T) {
+		assert.Equal(t, "linkedin", Referrer("link\xffedin", ""))
+	})
+}
+
+func TestViewerCookie(t *testing.T) {
+	const secret = "test-secret"
+	key := ViewerKey("anon:203.0.113.7|Mozilla/5.0")
+
+	t.Run("should round-trip a key it issued", func(t *testing.T) {
+		got, ok := ViewerFromCookie(ViewerCookie(key, secret), secret)
+
+		assert.True(t, ok)
+		assert.Equal(t, key, got)
+	})
+
+	t.Run("should reject keys made up by the client", func(t *testing.T) {
+		forged := ViewerKey("anything")
+
+		_, ok := ViewerFromCookie(forged, secret)
+		assert.False(t, ok)
+
+		_, ok = ViewerFromCookie(forged+"."+strings.Repeat("0", 64), secret)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject cookies signed with another secret", func(t *testing.T) {
+		_, ok := ViewerFromCookie(ViewerCookie(key, "other-secret"), secret)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestViewerKey(t *testing.T) {
+	t.Run("should hash identities to a stable key", func(t *testing.T) {
+		key := ViewerKey("user:123")
+
+		assert.Len(t, key, 64)
+		assert.Equal(t, key, ViewerKey("user:123"))
+		assert.NotEqual(t, key, ViewerKey("user:124"))
+	})
+}
@@ -0,0 +1,223 @@
+// Package compliance checks job postings against a configurable publishing
+// policy, such as pay-transparency laws requiring a salary range in some
+// jurisdictions or bans on wording like age requirements.
+package compliance
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+)
+
+// Checks a rule can perform.
+const (
+	// CheckSalaryRange requires both salary_min and salary_max.
+	CheckSalaryRange = "salary_range"
+	// CheckForbiddenTerms rejects fields matching any of the rule's patterns.
+	CheckForbiddenTerms = "forbidden_terms"
+)
+
+// Fields a forbidden_terms rule can inspect.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+)
+
+//go:embed default_policy.json
+var defaultPolicy []byte
+
+// Policy is the set of rules a job must satisfy before it is published.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is a single requirement, applied to the jobs within its scope.
+// Patterns are regular expressions matched against the field text
+// lowercased and without accents.
+type Rule struct {
+	ID       string   `json:"id"`
+	Check    string   `json:"check"`
+	Message  string   `json:"message"`
+	Scope    Scope    `json:"scope"`
+	Fields   []string `json:"fields,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// Scope limits a rule to jobs in the listed countries, states, cities and
+// types. Empty lists match every job, so a rule without a scope applies
+// everywhere. Jobs whose location could not be resolved only match rules
+// without a location scope.
+type Scope struct {
+	Countries []string         `json:"countries,omitempty"`
+	States    []string         `json:"states,omitempty"`
+	Cities    []string         `json:"cities,omitempty"`
+	Types     []models.JobType `json:"types,omitempty"`
+}
+
+// Violation is a rule a job does not satisfy.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Match   string `json:"match,omitempty"`
+}
+
+// Default returns the built-in policy, used when no policy file is
+// configured.
+func Default() *Policy {
+	policy, err := Parse(defaultPolicy)
+	if err != nil {
+		panic("compliance: invalid default policy: " + err.Error())
+	}
+	return policy
+}
+
+// Load reads the policy at path, or returns the default policy when path is
+// empty.
+func Load(path string) (*Policy, error) {
+	if path == "" {
+		return Default(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compliance policy: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a JSON policy.
+func Parse(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid compliance policy: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.ID == "" {
+			return nil, fmt.Errorf("invalid compliance policy: rule %d has no id", i+1)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("invalid compliance policy: duplicate rule %q", rule.ID)
+		}
+		seen[rule.ID] = true
+		if rule.Message == "" {
+			return nil, fmt.Errorf("invalid compliance policy: rule %q has no message", rule.ID)
+		}
+
+		switch rule.Check {
+		case CheckSalaryRange:
+		case CheckForbiddenTerms:
+			if len(rule.Fields) == 0 {
+				rule.Fields = []string{FieldTitle, FieldDescription}
+			}
+			for _, field := range rule.Fields {
+				if field != FieldTitle && field != FieldDescription {
+					return nil, fmt.Errorf("invalid compliance policy: rule %q checks unknown field %q", rule.ID, field)
+				}
+			}
+			if len(rule.Patterns) == 0 {
+				return nil, fmt.Errorf("invalid compliance policy: rule %q has no patterns", rule.ID)
+			}
+			for _, pattern := range rule.Patterns {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid compliance policy: rule %q: %w", rule.ID, err)
+				}
+				rule.patterns = append(rule.patterns, re)
+			}
+		default:
+			return nil, fmt.Errorf("invalid compliance policy: rule %q has unknown check %q", rule.ID, rule.Check)
+		}
+	}
+
+	return &policy, nil
+}
+
+// Evaluate returns every violation of the policy by job, in rule order. The
+// job's structured location must be resolved.
+func (p *Policy) Evaluate(job *models.Job) []Violation {
+	var violations []Violation
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.Scope.matches(job) {
+			continue
+		}
+
+		switch rule.Check {
+		case CheckSalaryRange:
+			if job.SalaryMin == nil || job.SalaryMax == nil {
+				violations = append(violations, Violation{Rule: rule.ID, Field: "salary_range", Message: rule.Message})
+			}
+		case CheckForbiddenTerms:
+			for _, field := range rule.Fields {
+				if match := rule.forbidden(fieldText(job, field)); match != "" {
+					violations = append(violations, Violation{Rule: rule.ID, Field: field, Message: rule.Message, Match: match})
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// forbidden returns the first text matched by the rule's patterns.
+func (r *Rule) forbidden(text string) string {
+	text = utils.NormalizeKey(text)
+	for _, re := range r.patterns {
+		if match := re.FindString(text); match != "" {
+			return match
+		}
+	}
+	return ""
+}
+
+func fieldText(job *models.Job, field string) string {
+	if field == FieldTitle {
+		return job.Title
+	}
+	return markdown.ToText(job.Description)
+}
+
+func (s Scope) matches(job *models.Job) bool {
+	return matchesAny(s.Countries, job.Country, strings.ToUpper) &&
+		matchesAny(s.States, job.State, strings.ToUpper) &&
+		matchesAny(s.Cities, job.City, utils.NormalizeKey) &&
+		matchesType(s.Types, job.Type)
+}
+
+func matchesAny(values []string, value string, normalize func(string) string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if normalize(v) == normalize(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(types []models.JobType, jobType models.JobType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == jobType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+const salaryPolicy = `{
+	"rules": [
+		{
+			"id": "salary-range-sp",
+			"check": "salary_range",
+			"message": "Jobs located in São Paulo must include a salary range",
+			"scope": {"states": ["sp"], "types": ["onsite", "hybrid"]}
+		}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("should load the default policy", func(t *testing.T) {
+		policy := Default()
+
+		assert.NotEmpty(t, policy.Rules)
+	})
+
+	t.Run("should reject unknown checks", func(t *testing.T) {
+		_, err := Parse([]byte(`{"rules": [{"id": "x", "check": "nope", "message": "m"}]}`))
+
+		assert.ErrorContains(t, err, `unknown check "nope"`)
+	})
+
+	t.Run("should reject duplicate rule ids", func(t *testing.T) {
+		_, err := Parse([]byte(`{"rules": [
+			{"id": "x", "check": "salary_range", "message": "m"},
+			{"id": "x", "check": "salary_range", "message": "m"}
+		]}`))
+
+		assert.ErrorContains(t, err, `duplicate rule "x"`)
+	})
+
+	t.Run("should reject invalid patterns", func(t *testing.T) {
+		_, err := Parse([]byte(`{"rules": [{"id": "x", "check": "forbidden_terms", "message": "m", "patterns": ["("]}]}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should default forbidden terms to title and description", func(t *testing.T) {
+		policy, err := Parse([]byte(`{"rules": [{"id": "x", "check": "forbidden_terms", "message": "m", "patterns": ["rockstar"]}]}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{FieldTitle, FieldDescription}, policy.Rules[0].Fields)
+	})
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := Parse([]byte(salaryPolicy))
+	require.NoError(t, err)
+
+	t.Run("should require a salary range in scope", func(t *testing.T) {
+		job := &models.Job{Title: "Dev", State: "SP", Country: "BR", Type: models.JobTypeOnsite, SalaryMin: float64Ptr(5000)}
+
+		violations := policy.Evaluate(job)
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, "salary-range-sp", violations[0].Rule)
+		assert.Equal(t, "salary_range", violations[0].Field)
+	})
+
+	t.Run("should pass jobs with a salary range", func(t *testing.T) {
+		job := &models.Job{Title: "Dev", State: "SP", Type: models.JobTypeOnsite, SalaryMin: float64Ptr(5000), SalaryMax: float64Ptr(7000)}
+
+		assert.Empty(t, policy.Evaluate(job))
+	})
+
+	t.Run("should ignore jobs out of scope", func(t *testing.T) {
+		assert.Empty(t, policy.Evaluate(&models.Job{Title: "Dev", State: "RJ", Type: models.JobTypeOnsite}))
+		assert.Empty(t, policy.Evaluate(&models.Job{Title: "Dev", State: "SP", Type: models.JobTypeRemote}))
+		assert.Empty(t, policy.Evaluate(&models.Job{Title: "Dev", Type: models.JobTypeOnsite}))
+	})
+
+	t.Run("should flag age requirements in the default policy", func(t *testing.T) {
+		job := &models.Job{
+			Title:       "Vendedor até 30 anos",
+			Description: "**Idade máxima**: 30 anos",
+			Type:        models.JobTypeOnsite,
+		}
+
+		violations := Default().Evaluate(job)
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, FieldTitle, violations[0].Field)
+		assert.Equal(t, "ate 30 anos", violations[0].Match)
+		assert.Equal(t, FieldDescription, violations[1].Field)
+		assert.Equal(t, "idade maxima", violations[1].Match)
+	})
+
+	t.Run("should not flag years of experience", func(t *testing.T) {
+		job := &models.Job{Title: "Desenvolvedor Go", Description: "De 3 a 5 anos de experiência, até 10 anos na área", Type: models.JobTypeRemote}
+
+		assert.Empty(t, Default().Evaluate(job))
+	})
+}
@@ -3,15 +3,21 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Server   ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	Server    ServerConfig
+	Scheduler SchedulerConfig
+	Jobs      JobsConfig
+	Mail      MailConfig
+	Org       OrganizationConfig
 }
 
 type DatabaseConfig struct {
@@ -31,6 +37,67 @@ type JWTConfig struct {
 type ServerConfig struct {
 	Port    string
 	GinMode string
+	// BaseURL is the public address of the API, used to build links in
+	// emails.
+	BaseURL string
+}
+
+type SchedulerConfig struct {
+	Interval time.Duration
+	// ViewFlushInterval is how often buffered job views are written to the
+	// database.
+	ViewFlushInterval time.Duration
+}
+
+type JobsConfig struct {
+	// DefaultExpiration closes new jobs this long after publication when no
+	// expires_at is given. Zero disables automatic expiry.
+	DefaultExpiration time.Duration
+	// AllowSelfApproval lets a recruiter approve their own job postings,
+	// which single-recruiter deployments need.
+	AllowSelfApproval bool
+	// FilledRejectionMessage is sent to pending candidates when a job that
+	// auto-rejects on fill closes and the job has no message of its own.
+	FilledRejectionMessage string
+	// BookmarkClosingNotice is how long before a bookmarked job expires the
+	// candidate is warned that it is about to close.
+	BookmarkClosingNotice time.Duration
+	// ClosingSoonWindow is how close to its deadline or expiry a job must be
+	// to match the closing_soon search filter.
+	ClosingSoonWindow time.Duration
+	// TrashRetention is how long deleted jobs stay in the trash before they
+	// are purged for good. Zero keeps them forever.
+	TrashRetention time.Duration
+	// CompliancePolicyFile is a JSON policy jobs must satisfy before they
+	// are published. Empty uses the built-in policy.
+	CompliancePolicyFile string
+	// LintWordLists are JSON word lists added to the bundled ones used to
+	// flag non-inclusive wording in job postings.
+	LintWordLists []string
+	// MunicipalitiesFile is a city,state,latitude,longitude CSV, such as
+	// the full IBGE table, replacing the bundled gazetteer used to resolve
+	// job locations and radius searches. Empty uses the bundled one.
+	MunicipalitiesFile string
+}
+
+type MailConfig struct {
+	// Driver selects how email is delivered: "file" writes each message to
+	// FileDir for local development, "smtp" sends it through the SMTP server.
+	Driver       string
+	From         string
+	FileDir      string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// OrganizationConfig describes the hiring organization in job feeds and
+// structured data.
+type OrganizationConfig struct {
+	Name    string
+	URL     string
+	LogoURL string
 }
 
 func Load() (*Config, error) {
@@ -48,6 +115,60 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRATION: %w", err)
 	}
 
+	schedulerInterval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_INTERVAL: %w", err)
+	}
+	if schedulerInterval <= 0 {
+		return nil, fmt.Errorf("invalid SCHEDULER_INTERVAL: must be positive")
+	}
+
+	viewFlushInterval, err := time.ParseDuration(getEnv("JOB_VIEW_FLUSH_INTERVAL", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_VIEW_FLUSH_INTERVAL: %w", err)
+	}
+	if viewFlushInterval <= 0 {
+		return nil, fmt.Errorf("invalid JOB_VIEW_FLUSH_INTERVAL: must be positive")
+	}
+
+	jobDefaultExp, err := time.ParseDuration(getEnv("JOB_DEFAULT_EXPIRATION", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_DEFAULT_EXPIRATION: %w", err)
+	}
+
+	allowSelfApproval, err := strconv.ParseBool(getEnv("JOB_APPROVAL_ALLOW_SELF", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_APPROVAL_ALLOW_SELF: %w", err)
+	}
+
+	bookmarkClosingNotice, err := time.ParseDuration(getEnv("BOOKMARK_CLOSING_NOTICE", "48h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOOKMARK_CLOSING_NOTICE: %w", err)
+	}
+
+	closingSoonWindow, err := time.ParseDuration(getEnv("JOB_CLOSING_SOON_WINDOW", "72h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_CLOSING_SOON_WINDOW: %w", err)
+	}
+	if closingSoonWindow <= 0 {
+		return nil, fmt.Errorf("invalid JOB_CLOSING_SOON_WINDOW: must be positive")
+	}
+
+	trashRetention, err := time.ParseDuration(getEnv("JOB_TRASH_RETENTION", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_TRASH_RETENTION: %w", err)
+	}
+	if trashRetention < 0 {
+		return nil, fmt.Errorf("invalid JOB_TRASH_RETENTION: must not be negative")
+	}
+
+	mailDriver := getEnv("MAIL_DRIVER", "file")
+	if mailDriver != "file" && mailDriver != "smtp" {
+		return nil, fmt.Errorf("invalid MAIL_DRIVER: must be file or smtp")
+	}
+
+	baseURL := strings.TrimRight(getEnv("APP_BASE_URL", "http://localhost:8080"), "/")
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -64,6 +185,39 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Port:    getEnv("PORT", "8080"),
 			GinMode: getEnv("GIN_MODE", "debug"),
+			BaseURL: baseURL,
+		},
+		Scheduler: SchedulerConfig{
+			Interval:          schedulerInterval,
+			ViewFlushInterval: viewFlushInterval,
+		},
+		Jobs: JobsConfig{
+			DefaultExpiration: jobDefaultExp,
+			AllowSelfApproval: allowSelfApproval,
+			FilledRejectionMessage: getEnv(
+				"JOB_FILLED_REJECTION_MESSAGE",
+				"Todas as vagas desta oportunidade foram preenchidas. Agradecemos o seu interesse.",
+			),
+			BookmarkClosingNotice: bookmarkClosingNotice,
+			ClosingSoonWindow:     closingSoonWindow,
+			TrashRetention:        trashRetention,
+			CompliancePolicyFile:  os.Getenv("JOB_COMPLIANCE_POLICY_FILE"),
+			LintWordLists:         splitList(os.Getenv("JOB_LINT_WORDLISTS")),
+			MunicipalitiesFile:    os.Getenv("GEO_MUNICIPALITIES_FILE"),
+		},
+		Mail: MailConfig{
+			Driver:       mailDriver,
+			From:         getEnv("MAIL_FROM", "Vagas <no-reply@recruitment.local>"),
+			FileDir:      getEnv("MAIL_FILE_DIR", "tmp/mail"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		},
+		Org: OrganizationConfig{
+			Name:    getEnv("ORGANIZATION_NAME", "Recruitment System"),
+			URL:     getEnv("ORGANIZATION_URL", baseURL),
+			LogoURL: os.Getenv("ORGANIZATION_LOGO_URL"),
 		},
 	}, nil
 }
@@ -75,6 +229,17 @@ func (d *DatabaseConfig) DSN() string {
 	)
 }
 
+// splitList splits a comma-separated value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
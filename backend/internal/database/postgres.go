@@ -3,9 +3,12 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/ledufranco/recruitment-system/internal/config"
 	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -30,6 +33,22 @@ func Migrate(db *gorm.DB) error {
 		&models.User{},
 		&models.Job{},
 		&models.Application{},
+		&models.JobReview{},
+		&models.JobRevision{},
+		&models.JobTemplate{},
+		&models.JobQuestion{},
+		&models.ApplicationAnswer{},
+		&models.KnockoutRule{},
+		&models.Skill{},
+		&models.SkillAlias{},
+		&models.JobSkill{},
+		&models.JobTeamMember{},
+		&models.SavedSearch{},
+		&models.Bookmark{},
+		&models.JobView{},
+		&models.JobTranslation{},
+		&models.JobSlug{},
+		&models.EmployerProfile{},
 	); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
@@ -42,6 +61,220 @@ func Migrate(db *gorm.DB) error {
 		return fmt.Errorf("failed to create unique index: %w", err)
 	}
 
+	if err := migrateLegacySalary(db); err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		UPDATE jobs SET published_at = created_at
+		WHERE published_at IS NULL AND status IN ('open', 'closed', 'archived')
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill published_at: %w", err)
+	}
+
+	if err := backfillJobLocations(db); err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		UPDATE jobs SET filled = counts.approved
+		FROM (
+			SELECT job_id, COUNT(*) AS approved FROM applications
+			WHERE status = 'approved' AND deleted_at IS NULL
+			GROUP BY job_id
+		) counts
+		WHERE jobs.id = counts.job_id AND jobs.filled <> counts.approved
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill filled openings: %w", err)
+	}
+
+	// Revisions recorded before the system flag existed only lack an editor.
+	if err := db.Exec(`
+		UPDATE job_revisions SET system = true
+		WHERE editor_id IS NULL AND NOT system
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill system revisions: %w", err)
+	}
+
+	if err := backfillDescriptionText(db); err != nil {
+		return err
+	}
+
+	if err := backfillJobSlugs(db); err != nil {
+		return err
+	}
+
+	if err := migrateSearchVectors(db); err != nil {
+		return err
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
+
+// migrateSearchVectors adds full-text search columns to jobs and their
+// translations, each stemmed with its locale's text search configuration.
+// The columns are generated, so PostgreSQL keeps them current. They index
+// the plain-text description, so Markdown syntax is not searchable; columns
+// created before that are rebuilt.
+func migrateSearchVectors(db *gorm.DB) error {
+	for _, table := range []string{"jobs", "job_translations"} {
+		if db.Migrator().HasColumn(table, "search_vector") {
+			var expression string
+			err := db.Raw(`
+				SELECT coalesce(generation_expression, '') FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = ? AND column_name = 'search_vector'
+			`, table).Scan(&expression).Error
+			if err != nil {
+				return fmt.Errorf("failed to inspect search vector of %s: %w", table, err)
+			}
+			if strings.Contains(expression, "description_text") {
+				continue
+			}
+			if err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN search_vector", table)).Error; err != nil {
+				return fmt.Errorf("failed to drop outdated search vector of %s: %w", table, err)
+			}
+		}
+
+		config := models.SearchConfigSQL("locale")
+		err := db.Exec(fmt.Sprintf(`
+			ALTER TABLE %[1]s ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector(%[2]s, coalesce(title, '')), 'A') ||
+				setweight(to_tsvector(%[2]s, coalesce(description_text, '')), 'B')
+			) STORED
+		`, table, config)).Error
+		if err != nil {
+			return fmt.Errorf("failed to add search vector to %s: %w", table, err)
+		}
+
+		err = db.Exec(fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS idx_%[1]s_search_vector ON %[1]s USING GIN (search_vector)", table,
+		)).Error
+		if err != nil {
+			return fmt.Errorf("failed to index search vector of %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// backfillDescriptionText fills the plain-text description of jobs and
+// translations saved before descriptions were rendered from Markdown.
+func backfillDescriptionText(db *gorm.DB) error {
+	var jobs []models.Job
+	if err := db.Unscoped().Select("id", "description").Where("description_text IS NULL").Find(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to load jobs for description backfill: %w", err)
+	}
+	for _, job := range jobs {
+		err := db.Unscoped().Model(&models.Job{}).Where("id = ?", job.ID).
+			UpdateColumn("description_text", markdown.ToText(job.Description)).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill job description: %w", err)
+		}
+	}
+
+	var translations []models.JobTranslation
+	if err := db.Select("job_id", "locale", "description").Where("description_text IS NULL").Find(&translations).Error; err != nil {
+		return fmt.Errorf("failed to load translations for description backfill: %w", err)
+	}
+	for _, t := range translations {
+		err := db.Model(&models.JobTranslation{}).Where("job_id = ? AND locale = ?", t.JobID, t.Locale).
+			UpdateColumn("description_text", markdown.ToText(t.Description)).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill translation description: %w", err)
+		}
+	}
+
+	if n := len(jobs) + len(translations); n > 0 {
+		log.Printf("Extracted plain-text descriptions for %d jobs and translations", n)
+	}
+	return nil
+}
+
+// backfillJobSlugs gives jobs created before slugs existed a slug from
+// their title, oldest first so the first job with a title keeps the plain
+// slug.
+func backfillJobSlugs(db *gorm.DB) error {
+	var jobs []models.Job
+	if err := db.Unscoped().Select("id", "title").Where("slug IS NULL").Order("created_at").Find(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to load jobs for slug backfill: %w", err)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	var taken []string
+	if err := db.Raw("SELECT slug FROM jobs WHERE slug IS NOT NULL UNION SELECT slug FROM job_slugs").Scan(&taken).Error; err != nil {
+		return fmt.Errorf("failed to load job slugs: %w", err)
+	}
+	used := models.UsedJobSlugs(taken)
+
+	for _, job := range jobs {
+		slug := utils.UniqueSlug(models.JobSlugBase(job.Title), used)
+		used[slug] = true
+		err := db.Unscoped().Model(&models.Job{}).Where("id = ?", job.ID).UpdateColumn("slug", slug).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill job slug: %w", err)
+		}
+	}
+
+	log.Printf("Generated slugs for %d jobs", len(jobs))
+	return nil
+}
+
+// backfillJobLocations resolves the structured location of jobs created
+// before it existed. Jobs whose location is not in the gazetteer get an
+// empty country so they are not retried on every start.
+func backfillJobLocations(db *gorm.DB) error {
+	var jobs []models.Job
+	if err := db.Unscoped().Select("id", "location").Where("country IS NULL").Find(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to load jobs for location backfill: %w", err)
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		job.ResolveLocation()
+		err := db.Unscoped().Model(&models.Job{}).Where("id = ?", job.ID).UpdateColumns(map[string]interface{}{
+			"city":      job.City,
+			"state":     job.State,
+			"country":   job.Country,
+			"latitude":  job.Latitude,
+			"longitude": job.Longitude,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill job location: %w", err)
+		}
+	}
+
+	if len(jobs) > 0 {
+		log.Printf("Resolved structured location for %d jobs", len(jobs))
+	}
+	return nil
+}
+
+// migrateLegacySalary moves the single "salary" column used before salary
+// ranges into salary_min/salary_max (as a monthly BRL amount) and drops it.
+func migrateLegacySalary(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Job{}, "salary") {
+		return nil
+	}
+
+	if err := db.Exec(`
+		UPDATE jobs
+		SET salary_min = salary,
+			salary_max = salary,
+			salary_annual_min = salary * 12,
+			salary_annual_max = salary * 12,
+			salary_currency = 'BRL',
+			salary_period = 'month'
+		WHERE salary IS NOT NULL AND salary_min IS NULL AND salary_max IS NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to migrate legacy salary: %w", err)
+	}
+
+	if err := db.Migrator().DropColumn(&models.Job{}, "salary"); err != nil {
+		return fmt.Errorf("failed to drop legacy salary column: %w", err)
+	}
+
+	log.Println("Migrated legacy job salaries to salary ranges")
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package feeds renders open jobs for syndication: RSS 2.0, Atom 1.0, an
+// XML feed in the format job boards and aggregators ingest, and schema.org
+// JobPosting structured data.
+package feeds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/models"
+)
+
+// Source identifies who publishes the feeds and where jobs are linked to.
+type Source struct {
+	BaseURL string
+	Org     config.OrganizationConfig
+}
+
+// JobURL is the public address of the job.
+func (s Source) JobURL(job *models.Job) string {
+	return s.BaseURL + "/api/jobs/" + job.ID.String()
+}
+
+// postedAt is when the job was published, falling back to its creation for
+// jobs published before published_at was recorded.
+func postedAt(job *models.Job) time.Time {
+	if job.PublishedAt != nil {
+		return *job.PublishedAt
+	}
+	return job.CreatedAt
+}
+
+// lastUpdated is the most recent change among jobs, or now for an empty feed.
+func lastUpdated(jobs []models.Job, now time.Time) time.Time {
+	if len(jobs) == 0 {
+		return now
+	}
+	latest := jobs[0].UpdatedAt
+	for _, job := range jobs[1:] {
+		if job.UpdatedAt.After(latest) {
+			latest = job.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// salaryText describes the disclosed salary range, or "" when there is none.
+func salaryText(job *models.Job) string {
+	switch {
+	case job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin != *job.SalaryMax:
+		return fmt.Sprintf("%s %.2f - %.2f per %s", job.SalaryCurrency, *job.SalaryMin, *job.SalaryMax, job.SalaryPeriod)
+	case job.SalaryMin != nil:
+		return fmt.Sprintf("%s %.2f per %s", job.SalaryCurrency, *job.SalaryMin, job.SalaryPeriod)
+	case job.SalaryMax != nil:
+		return fmt.Sprintf("up to %s %.2f per %s", job.SalaryCurrency, *job.SalaryMax, job.SalaryPeriod)
+	case job.SalaryNegotiable:
+		return "Negotiable"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,173 @@
+package feeds
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var src = Source{
+	BaseURL: "https://vagas.example.com",
+	Org:     config.OrganizationConfig{Name: "Acme", URL: "https://acme.example.com"},
+}
+
+func testJob(jobType models.JobType) models.Job {
+	published := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	expires := published.AddDate(0, 1, 0)
+	salaryMin, salaryMax := 8000.0, 12000.0
+	return models.Job{
+		ID:             uuid.New(),
+		Title:          "Desenvolvedor Go",
+		Description:    "APIs em Go & PostgreSQL.\n\nRequisitos: <3 anos>",
+		Location:       "Niterói, RJ",
+		City:           "Niterói",
+		State:          "RJ",
+		Country:        "BR",
+		Type:           jobType,
+		Status:         models.JobStatusOpen,
+		Openings:       2,
+		SalaryMin:      &salaryMin,
+		SalaryMax:      &salaryMax,
+		SalaryCurrency: "BRL",
+		SalaryPeriod:   models.SalaryPeriodMonth,
+		PublishedAt:    &published,
+		ExpiresAt:      &expires,
+		CreatedAt:      published,
+		UpdatedAt:      published,
+	}
+}
+
+func TestNewJobPosting(t *testing.T) {
+	t.Run("should describe an on-site job with its address and salary", func(t *testing.T) {
+		job := testJob(models.JobTypeOnsite)
+
+		posting := NewJobPosting(src, &job)
+
+		assert.Equal(t, "JobPosting", posting.Type)
+		assert.Empty(t, posting.JobLocationType)
+		assert.Nil(t, posting.ApplicantLocationRequirements)
+		require.NotNil(t, posting.JobLocation)
+		assert.Equal(t, "Niterói", posting.JobLocation.Address.AddressLocality)
+		assert.Equal(t, "RJ", posting.JobLocation.Address.AddressRegion)
+		assert.Equal(t, "2024-04-01T09:00:00Z", posting.ValidThrough)
+		require.NotNil(t, posting.BaseSalary)
+		assert.Equal(t, "MONTH", posting.BaseSalary.Value.UnitText)
+		assert.Equal(t, 8000.0, *posting.BaseSalary.Value.MinValue)
+		assert.Equal(t, "<p>APIs em Go &amp; PostgreSQL.</p>\n<p>Requisitos: &lt;3 anos&gt;</p>\n", posting.Description)
+	})
+
+	t.Run("should end validity at an application deadline before the expiry", func(t *testing.T) {
+		job := testJob(models.JobTypeOnsite)
+		deadline := time.Date(2024, 3, 20, 23, 59, 0, 0, time.UTC)
+		job.ApplicationDeadline = &deadline
+
+		posting := NewJobPosting(src, &job)
+
+		assert.Equal(t, "2024-03-20T23:59:00Z", posting.ValidThrough)
+	})
+
+	t.Run("should mark remote jobs as telecommute without an address", func(t *testing.T) {
+		job := testJob(models.JobTypeRemote)
+
+		posting := NewJobPosting(src, &job)
+
+		assert.Equal(t, "TELECOMMUTE", posting.JobLocationType)
+		assert.Nil(t, posting.JobLocation)
+		require.NotNil(t, posting.ApplicantLocationRequirements)
+		assert.Equal(t, "BR", posting.ApplicantLocationRequirements.Name)
+	})
+
+	t.Run("should give hybrid jobs both an address and telecommute", func(t *testing.T) {
+		job := testJob(models.JobTypeHybrid)
+
+		posting := NewJobPosting(src, &job)
+
+		assert.Equal(t, "TELECOMMUTE", posting.JobLocationType)
+		assert.NotNil(t, posting.JobLocation)
+	})
+
+	t.Run("should map the contract type to employmentType", func(t *testing.T) {
+		for contractType, employmentType := range map[models.ContractType]string{
+			models.ContractTypeFullTime:   "FULL_TIME",
+			models.ContractTypePartTime:   "PART_TIME",
+			models.ContractTypeContractor: "CONTRACTOR",
+			models.ContractTypeTemporary:  "TEMPORARY",
+			models.ContractTypeInternship: "INTERN",
+		} {
+			job := testJob(models.JobTypeOnsite)
+			job.ContractType = contractType
+
+			posting := NewJobPosting(src, &job)
+
+			assert.Equal(t, employmentType, posting.EmploymentType, contractType)
+		}
+	})
+
+	t.Run("should serialize schema.org keys", func(t *testing.T) {
+		job := testJob(models.JobTypeOnsite)
+		job.ContractType = models.ContractTypeContractor
+
+		body, err := json.Marshal(NewJobPosting(src, &job))
+
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"@context":"https://schema.org/"`)
+		assert.Contains(t, string(body), `"employmentType":"CONTRACTOR"`)
+	})
+
+	t.Run("should leave employmentType out when the contract type is not stated", func(t *testing.T) {
+		job := testJob(models.JobTypeOnsite)
+
+		body, err := json.Marshal(NewJobPosting(src, &job))
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "employmentType")
+	})
+}
+
+func TestSyndicationFeeds(t *testing.T) {
+	jobs := []models.Job{testJob(models.JobTypeRemote), testJob(models.JobTypeOnsite)}
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should render a well-formed RSS channel", func(t *testing.T) {
+		body, err := RSS(src, jobs, src.BaseURL+"/feeds/jobs.rss", now)
+		require.NoError(t, err)
+
+		var feed rssFeed
+		require.NoError(t, xml.Unmarshal(body, &feed))
+		assert.Len(t, feed.Channel.Items, 2)
+		assert.Equal(t, src.JobURL(&jobs[0]), feed.Channel.Items[0].Link)
+		assert.Equal(t, "Fri, 01 Mar 2024 09:00:00 +0000", feed.Channel.Items[0].PubDate)
+	})
+
+	t.Run("should render a well-formed Atom feed", func(t *testing.T) {
+		body, err := Atom(src, jobs, src.BaseURL+"/feeds/jobs.atom", now)
+		require.NoError(t, err)
+
+		var feed atomFeed
+		require.NoError(t, xml.Unmarshal(body, &feed))
+		assert.Len(t, feed.Entries, 2)
+		assert.Equal(t, "urn:uuid:"+jobs[1].ID.String(), feed.Entries[1].ID)
+		assert.Equal(t, "2024-03-01T09:00:00Z", feed.Updated)
+	})
+
+	t.Run("should render the job board feed with remote types", func(t *testing.T) {
+		body, err := JobBoard(src, jobs, now)
+		require.NoError(t, err)
+
+		var feed jobBoardFeed
+		require.NoError(t, xml.Unmarshal(body, &feed))
+		require.Len(t, feed.Jobs, 2)
+		assert.Equal(t, "Fully remote", feed.Jobs[0].RemoteType.Value)
+		assert.Nil(t, feed.Jobs[1].RemoteType)
+		assert.Equal(t, "BRL 8000.00 - 12000.00 per month", feed.Jobs[1].Salary.Value)
+		assert.True(t, strings.Contains(string(body), "<![CDATA[Desenvolvedor Go]]>"))
+	})
+}
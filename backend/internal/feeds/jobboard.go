@@ -0,0 +1,85 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+)
+
+// jobBoardFeed follows the XML layout job boards and aggregators ingest
+// (publisher header plus one <job> per opening with CDATA fields).
+type jobBoardFeed struct {
+	XMLName       xml.Name      `xml:"source"`
+	Publisher     string        `xml:"publisher"`
+	PublisherURL  string        `xml:"publisherurl"`
+	LastBuildDate string        `xml:"lastBuildDate"`
+	Jobs          []jobBoardJob `xml:"job"`
+}
+
+type jobBoardJob struct {
+	Title           cdata  `xml:"title"`
+	Date            cdata  `xml:"date"`
+	ReferenceNumber cdata  `xml:"referencenumber"`
+	URL             cdata  `xml:"url"`
+	Company         cdata  `xml:"company"`
+	City            cdata  `xml:"city"`
+	State           cdata  `xml:"state"`
+	Country         cdata  `xml:"country"`
+	Description     cdata  `xml:"description"`
+	Salary          *cdata `xml:"salary,omitempty"`
+	RemoteType      *cdata `xml:"remotetype,omitempty"`
+	ExpirationDate  *cdata `xml:"expirationdate,omitempty"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+// remoteTypes maps JobType to the aggregators' remotetype values. On-site
+// jobs carry no remotetype.
+var remoteTypes = map[models.JobType]string{
+	models.JobTypeRemote: "Fully remote",
+	models.JobTypeHybrid: "Hybrid remote",
+}
+
+// JobBoard renders jobs in the job-board XML format.
+func JobBoard(src Source, jobs []models.Job, now time.Time) ([]byte, error) {
+	feed := jobBoardFeed{
+		Publisher:     src.Org.Name,
+		PublisherURL:  src.Org.URL,
+		LastBuildDate: lastUpdated(jobs, now).Format(time.RFC1123Z),
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		entry := jobBoardJob{
+			Title:           cdata{job.Title},
+			Date:            cdata{postedAt(job).Format(time.RFC1123Z)},
+			ReferenceNumber: cdata{job.ID.String()},
+			URL:             cdata{src.JobURL(job)},
+			Company:         cdata{src.Org.Name},
+			City:            cdata{job.City},
+			State:           cdata{job.State},
+			Country:         cdata{job.Country},
+			Description:     cdata{markdown.ToText(job.Description)},
+		}
+		if job.City == "" && job.Type != models.JobTypeRemote {
+			// Unresolved locations are passed on as written.
+			entry.City = cdata{job.Location}
+		}
+		if salary := salaryText(job); salary != "" {
+			entry.Salary = &cdata{salary}
+		}
+		if remoteType, ok := remoteTypes[job.Type]; ok {
+			entry.RemoteType = &cdata{remoteType}
+		}
+		if closeAt := job.ApplicationsCloseAt(); closeAt != nil {
+			entry.ExpirationDate = &cdata{closeAt.Format(time.RFC1123Z)}
+		}
+		feed.Jobs = append(feed.Jobs, entry)
+	}
+
+	return marshalXML(feed)
+}
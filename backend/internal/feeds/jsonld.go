@@ -0,0 +1,162 @@
+package feeds
+
+import (
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+)
+
+// JobPosting is the schema.org JobPosting structured data search engines
+// read to list jobs. Only the properties the job model can back are set.
+type JobPosting struct {
+	Context                       string              `json:"@context"`
+	Type                          string              `json:"@type"`
+	Title                         string              `json:"title"`
+	Description                   string              `json:"description"`
+	Identifier                    PropertyValue       `json:"identifier"`
+	DatePosted                    string              `json:"datePosted"`
+	ValidThrough                  string              `json:"validThrough,omitempty"`
+	EmploymentType                string              `json:"employmentType,omitempty"`
+	URL                           string              `json:"url"`
+	HiringOrganization            Organization        `json:"hiringOrganization"`
+	JobLocation                   *Place              `json:"jobLocation,omitempty"`
+	JobLocationType               string              `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements *AdministrativeArea `json:"applicantLocationRequirements,omitempty"`
+	BaseSalary                    *MonetaryAmount     `json:"baseSalary,omitempty"`
+	TotalJobOpenings              int                 `json:"totalJobOpenings,omitempty"`
+}
+
+type PropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Organization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+type Place struct {
+	Type    string        `json:"@type"`
+	Address PostalAddress `json:"address"`
+}
+
+type PostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+type AdministrativeArea struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type MonetaryAmount struct {
+	Type     string            `json:"@type"`
+	Currency string            `json:"currency"`
+	Value    QuantitativeValue `json:"value"`
+}
+
+type QuantitativeValue struct {
+	Type     string   `json:"@type"`
+	Value    *float64 `json:"value,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText"`
+}
+
+// salaryUnits maps SalaryPeriod to schema.org unitText.
+var salaryUnits = map[models.SalaryPeriod]string{
+	models.SalaryPeriodHour:  "HOUR",
+	models.SalaryPeriodMonth: "MONTH",
+	models.SalaryPeriodYear:  "YEAR",
+}
+
+// employmentTypes maps ContractType to schema.org employmentType values.
+// Jobs that do not state a contract type carry no employmentType.
+var employmentTypes = map[models.ContractType]string{
+	models.ContractTypeFullTime:   "FULL_TIME",
+	models.ContractTypePartTime:   "PART_TIME",
+	models.ContractTypeContractor: "CONTRACTOR",
+	models.ContractTypeTemporary:  "TEMPORARY",
+	models.ContractTypeInternship: "INTERN",
+}
+
+// NewJobPosting builds the structured data for job.
+//
+// JobType says where the work happens, so it maps to jobLocationType:
+// remote and hybrid jobs are TELECOMMUTE, and only jobs with an office
+// (on-site and hybrid) get a jobLocation. ContractType maps to
+// employmentType.
+func NewJobPosting(src Source, job *models.Job) JobPosting {
+	posting := JobPosting{
+		Context:     "https://schema.org/",
+		Type:        "JobPosting",
+		Title:       job.Title,
+		Description: markdown.ToHTML(job.Description),
+		Identifier: PropertyValue{
+			Type:  "PropertyValue",
+			Name:  src.Org.Name,
+			Value: job.ID.String(),
+		},
+		DatePosted: postedAt(job).Format(time.RFC3339),
+		URL:        src.JobURL(job),
+		HiringOrganization: Organization{
+			Type:   "Organization",
+			Name:   src.Org.Name,
+			SameAs: src.Org.URL,
+			Logo:   src.Org.LogoURL,
+		},
+		EmploymentType:   employmentTypes[job.ContractType],
+		TotalJobOpenings: job.Openings,
+	}
+
+	// Boards drop the posting once it stops taking applications, which an
+	// application deadline can make earlier than the expiry.
+	if closeAt := job.ApplicationsCloseAt(); closeAt != nil {
+		posting.ValidThrough = closeAt.Format(time.RFC3339)
+	}
+
+	country := job.Country
+	if country == "" {
+		country = geo.Country
+	}
+
+	if job.Type == models.JobTypeRemote || job.Type == models.JobTypeHybrid {
+		posting.JobLocationType = "TELECOMMUTE"
+	}
+	if job.Type == models.JobTypeRemote {
+		posting.ApplicantLocationRequirements = &AdministrativeArea{Type: "Country", Name: country}
+	} else {
+		address := PostalAddress{
+			Type:            "PostalAddress",
+			AddressLocality: job.City,
+			AddressRegion:   job.State,
+			AddressCountry:  country,
+		}
+		if address.AddressLocality == "" {
+			address.AddressLocality = job.Location
+		}
+		posting.JobLocation = &Place{Type: "Place", Address: address}
+	}
+
+	if job.SalaryMin != nil || job.SalaryMax != nil {
+		value := QuantitativeValue{Type: "QuantitativeValue", UnitText: salaryUnits[job.SalaryPeriod]}
+		if job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin == *job.SalaryMax {
+			value.Value = job.SalaryMin
+		} else {
+			value.MinValue = job.SalaryMin
+			value.MaxValue = job.SalaryMax
+		}
+		posting.BaseSalary = &MonetaryAmount{Type: "MonetaryAmount", Currency: job.SalaryCurrency, Value: value}
+	}
+
+	return posting
+}
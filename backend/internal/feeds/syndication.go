@@ -0,0 +1,149 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	NS      string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    atomText       `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// RSS renders jobs as an RSS 2.0 channel. selfURL is the feed's own address.
+func RSS(src Source, jobs []models.Job, selfURL string, now time.Time) ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         "Vagas - " + src.Org.Name,
+			Link:          src.Org.URL,
+			Description:   "Vagas abertas em " + src.Org.Name,
+			Language:      "pt-BR",
+			LastBuildDate: lastUpdated(jobs, now).Format(time.RFC1123Z),
+			Self:          atomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       job.Title + " (" + job.Location + ")",
+			Link:        src.JobURL(job),
+			Description: markdown.ToText(job.Description),
+			GUID:        rssGUID{Value: "urn:uuid:" + job.ID.String()},
+			PubDate:     postedAt(job).Format(time.RFC1123Z),
+			Categories:  []string{string(job.Type)},
+		})
+	}
+
+	return marshalXML(feed)
+}
+
+// Atom renders jobs as an Atom 1.0 feed. selfURL is the feed's own address.
+func Atom(src Source, jobs []models.Job, selfURL string, now time.Time) ([]byte, error) {
+	feed := atomFeed{
+		NS:      "http://www.w3.org/2005/Atom",
+		Title:   "Vagas - " + src.Org.Name,
+		ID:      selfURL,
+		Updated: lastUpdated(jobs, now).Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: src.Org.URL, Rel: "alternate"},
+		},
+		Author: atomAuthor{Name: src.Org.Name, URI: src.Org.URL},
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:      job.Title + " (" + job.Location + ")",
+			ID:         "urn:uuid:" + job.ID.String(),
+			Link:       atomLink{Href: src.JobURL(job), Rel: "alternate"},
+			Published:  postedAt(job).Format(time.RFC3339),
+			Updated:    job.UpdatedAt.Format(time.RFC3339),
+			Summary:    atomText{Type: "text", Value: markdown.ToText(job.Description)},
+			Categories: []atomCategory{{Term: string(job.Type)}},
+		})
+	}
+
+	return marshalXML(feed)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/config"
 	"github.com/ledufranco/recruitment-system/internal/middleware"
 	"github.com/ledufranco/recruitment-system/internal/models"
 	"github.com/ledufranco/recruitment-system/internal/repository"
@@ -16,26 +20,47 @@ import (
 type ApplicationHandler struct {
 	applicationRepo *repository.ApplicationRepository
 	jobRepo         *repository.JobRepository
+	ruleRepo        *repository.KnockoutRuleRepository
+	userRepo        *repository.UserRepository
+	access          *JobAccess
+	cfg             *config.Config
 }
 
 type CreateApplicationRequest struct {
-	JobID uuid.UUID `json:"job_id" binding:"required"`
+	JobID   uuid.UUID       `json:"job_id" binding:"required"`
+	Answers []AnswerRequest `json:"answers"`
+}
+
+type AnswerRequest struct {
+	QuestionID uuid.UUID       `json:"question_id" binding:"required"`
+	Value      json.RawMessage `json:"value" swaggertype:"object"`
 }
 
 type UpdateApplicationStatusRequest struct {
 	Status models.ApplicationStatus `json:"status" binding:"required,oneof=pending reviewing approved rejected"`
 }
 
-func NewApplicationHandler(applicationRepo *repository.ApplicationRepository, jobRepo *repository.JobRepository) *ApplicationHandler {
+func NewApplicationHandler(
+	applicationRepo *repository.ApplicationRepository,
+	jobRepo *repository.JobRepository,
+	ruleRepo *repository.KnockoutRuleRepository,
+	userRepo *repository.UserRepository,
+	access *JobAccess,
+	cfg *config.Config,
+) *ApplicationHandler {
 	return &ApplicationHandler{
 		applicationRepo: applicationRepo,
 		jobRepo:         jobRepo,
+		ruleRepo:        ruleRepo,
+		userRepo:        userRepo,
+		access:          access,
+		cfg:             cfg,
 	}
 }
 
 // Create godoc
 // @Summary      Criar candidatura
-// @Description  Candidata-se a uma vaga (apenas candidates)
+// @Description  Candidata-se a uma vaga (apenas candidates). As respostas são avaliadas pelas regras eliminatórias da vaga, que podem rejeitar ou sinalizar a candidatura. Após o prazo de candidatura (`application_deadline`) da vaga, a candidatura é recusada com 400. A origem da candidatura vem apenas do cookie assinado application_source_<id da vaga> gravado ao abrir a mesma vaga; indicações só valem para funcionários (admins).
 // @Tags         applications
 // @Accept       json
 // @Produce      json
@@ -69,7 +94,14 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		return
 	}
 
-	if job.Status != models.JobStatusOpen {
+	if job.Status == models.JobStatusOpen && job.DeadlinePassed(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":                "The application deadline for this job has passed",
+			"application_deadline": job.ApplicationDeadline,
+		})
+		return
+	}
+	if !job.AcceptsApplications(time.Now()) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Job is not accepting applications"})
 		return
 	}
@@ -84,10 +116,57 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		return
 	}
 
+	rules, err := h.ruleRepo.FindByJobID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get knockout rules"})
+		return
+	}
+
+	// Questions used by knockout rules must be answered.
+	questions := make([]models.JobQuestion, len(job.Questions))
+	copy(questions, job.Questions)
+	for i := range questions {
+		for _, rule := range rules {
+			if rule.QuestionID == questions[i].ID {
+				questions[i].Required = true
+			}
+		}
+	}
+
+	answers, answerErrors := buildAnswers(questions, req.Answers)
+	if answerErrors != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answers", "answers": answerErrors})
+		return
+	}
+
+	revision, err := h.jobRepo.CurrentRevision(job)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job revision"})
+		return
+	}
+
+	source, err := h.applicationSource(c, req.JobID, claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check referral"})
+		return
+	}
+
 	application := &models.Application{
-		JobID:       req.JobID,
-		CandidateID: claims.UserID,
-		Status:      models.ApplicationStatusPending,
+		JobID:         req.JobID,
+		CandidateID:   claims.UserID,
+		Status:        models.ApplicationStatusPending,
+		JobRevisionID: &revision.ID,
+		Source:        source,
+		Answers:       answers,
+	}
+
+	answerValues := make(map[uuid.UUID]json.RawMessage, len(answers))
+	for _, answer := range answers {
+		answerValues[answer.QuestionID] = json.RawMessage(answer.Value)
+	}
+	now := time.Now()
+	if rule := models.EvaluateKnockout(rules, questions, answerValues); rule != nil {
+		application.ApplyKnockout(rule, now)
 	}
 
 	if err := h.applicationRepo.Create(application); err != nil {
@@ -97,7 +176,47 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 
 	application, _ = h.applicationRepo.FindByID(application.ID)
 
-	c.JSON(http.StatusCreated, application.ToResponse(true, false))
+	c.JSON(http.StatusCreated, application.ToCandidateResponse(now))
+}
+
+// applicationSource returns where the candidate came from, as remembered
+// in the job's signed cookie when they opened it. Clients cannot set it
+// themselves, and a cookie captured for another job is ignored. Referrals
+// by anyone but an employee other than the candidate are dropped, without
+// failing the application.
+func (h *ApplicationHandler) applicationSource(c *gin.Context, jobID, candidateID uuid.UUID) (models.ApplicationSource, error) {
+	source := analytics.Normalize(models.ApplicationSource{})
+	if cookie, err := c.Cookie(sourceCookieName(jobID)); err == nil {
+		source = analytics.DecodeSource(cookie, jobID, h.cfg.JWT.Secret)
+	}
+
+	if source.ReferredByID != nil {
+		valid := *source.ReferredByID != candidateID
+		if valid {
+			var err error
+			if valid, err = isEmployee(h.userRepo, *source.ReferredByID); err != nil {
+				return source, err
+			}
+		}
+		if !valid {
+			source.ReferredByID = nil
+			source.ResolveChannel()
+		}
+	}
+	return source, nil
+}
+
+// isEmployee reports whether id is an existing admin user, the only users
+// who can refer candidates.
+func isEmployee(userRepo *repository.UserRepository, id uuid.UUID) (bool, error) {
+	user, err := userRepo.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.Role == models.RoleAdmin, nil
 }
 
 // GetMyApplications godoc
@@ -122,9 +241,10 @@ func (h *ApplicationHandler) GetMyApplications(c *gin.Context) {
 		return
 	}
 
+	now := time.Now()
 	responses := make([]models.ApplicationResponse, len(applications))
 	for i, app := range applications {
-		responses[i] = app.ToResponse(true, false)
+		responses[i] = app.ToCandidateResponse(now)
 	}
 
 	c.JSON(http.StatusOK, responses)
@@ -132,7 +252,7 @@ func (h *ApplicationHandler) GetMyApplications(c *gin.Context) {
 
 // GetJobApplications godoc
 // @Summary      Obter candidaturas de uma vaga
-// @Description  Retorna todas as candidaturas de uma vaga específica (apenas o admin que criou a vaga)
+// @Description  Retorna todas as candidaturas de uma vaga específica (equipe de contratação da vaga)
 // @Tags         applications
 // @Accept       json
 // @Produce      json
@@ -152,9 +272,6 @@ func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
 		return
 	}
 
-	userClaims, _ := c.Get(middleware.UserContextKey)
-	claims := userClaims.(*jwt.Claims)
-
 	job, err := h.jobRepo.FindByID(jobID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -165,8 +282,7 @@ func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
 		return
 	}
 
-	if job.RecruiterID != claims.UserID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only view applications for your own jobs"})
+	if !h.access.Authorize(c, job, models.CapabilityViewApplications, "You can only view applications for jobs you are on the team of") {
 		return
 	}
 
@@ -186,7 +302,7 @@ func (h *ApplicationHandler) GetJobApplications(c *gin.Context) {
 
 // UpdateStatus godoc
 // @Summary      Atualizar status de candidatura
-// @Description  Atualiza o status de uma candidatura (apenas admin da vaga)
+// @Description  Atualiza o status de uma candidatura (owner, recruiter ou hiring manager da vaga). Aprovar a última posição em aberto encerra a vaga.
 // @Tags         applications
 // @Accept       json
 // @Produce      json
@@ -213,9 +329,6 @@ func (h *ApplicationHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	userClaims, _ := c.Get(middleware.UserContextKey)
-	claims := userClaims.(*jwt.Claims)
-
 	application, err := h.applicationRepo.FindByID(applicationID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -232,16 +345,78 @@ func (h *ApplicationHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	if job.RecruiterID != claims.UserID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update applications for your own jobs"})
+	if !h.access.Authorize(c, job, models.CapabilityManageApplicants, "You can only update applications for jobs you manage") {
 		return
 	}
 
+	previousStatus := application.Status
 	application.Status = req.Status
-	if err := h.applicationRepo.Update(application); err != nil {
+	application.StatusMessage = ""
+	application.RejectionVisibleAt = nil
+
+	// Approving or un-approving changes the job's filled openings, which are
+	// recounted in the same transaction as the status change.
+	if previousStatus == models.ApplicationStatusApproved || req.Status == models.ApplicationStatusApproved {
+		userClaims, _ := c.Get(middleware.UserContextKey)
+		claims := userClaims.(*jwt.Claims)
+
+		synced, _, err := h.jobRepo.SaveApplicationAndSyncOpenings(application, h.cfg.Jobs.FilledRejectionMessage, &claims.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application"})
+			return
+		}
+		application.Job = *synced
+	} else if err := h.applicationRepo.Update(application); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application"})
 		return
 	}
 
 	c.JSON(http.StatusOK, application.ToResponse(true, true))
 }
+
+// buildAnswers validates the submitted answers against the job's screening
+// questions. Validation problems are returned keyed by question ID (or by
+// the submitted ID for unknown questions).
+func buildAnswers(questions []models.JobQuestion, submitted []AnswerRequest) ([]models.ApplicationAnswer, map[string]string) {
+	errs := make(map[string]string)
+
+	values := make(map[uuid.UUID]json.RawMessage, len(submitted))
+	for _, answer := range submitted {
+		if _, dup := values[answer.QuestionID]; dup {
+			errs[answer.QuestionID.String()] = "answered more than once"
+			continue
+		}
+		values[answer.QuestionID] = answer.Value
+	}
+
+	known := make(map[uuid.UUID]bool, len(questions))
+	answers := make([]models.ApplicationAnswer, 0, len(questions))
+	for _, question := range questions {
+		known[question.ID] = true
+		value := values[question.ID]
+		if err := question.ValidateAnswer(value); err != nil {
+			errs[question.ID.String()] = err.Error()
+			continue
+		}
+		if models.IsEmptyAnswer(value) {
+			continue
+		}
+		answers = append(answers, models.ApplicationAnswer{
+			QuestionID: question.ID,
+			Prompt:     question.Prompt,
+			Type:       question.Type,
+			Value:      models.JSONValue(value),
+		})
+	}
+
+	for id := range values {
+		if !known[id] {
+			errs[id.String()] = "unknown question"
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return answers, nil
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationHandler_UpdateStatus(t *testing.T) {
+	recruiterID := uuid.New()
+
+	openJob := func() *models.Job {
+		return &models.Job{
+			ID:          uuid.New(),
+			Title:       "Desenvolvedor Go",
+			Description: "APIs em Go.",
+			Location:    "Niterói, RJ",
+			Type:        models.JobTypeOnsite,
+			Status:      models.JobStatusOpen,
+			RecruiterID: recruiterID,
+			Openings:    2,
+			Slug:        "desenvolvedor-go",
+		}
+	}
+	expectFindApplication := func(mock sqlmock.Sqlmock, applicationID uuid.UUID, job *models.Job, status models.ApplicationStatus) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "applications" WHERE id = $1`)).
+			WithArgs(applicationID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "candidate_id", "status"}).
+				AddRow(applicationID, job.ID, uuid.New(), status))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "application_answers"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs"`)).
+			WillReturnRows(jobRow(job))
+		expectFindJob(mock, job)
+	}
+	updateStatus := func(t *testing.T, h *ApplicationHandler, applicationID uuid.UUID, status models.ApplicationStatus) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodPut, "/api/applications/"+applicationID.String()+"/status",
+			gin.H{"status": status}, recruiterID, models.RoleAdmin,
+			gin.Param{Key: "id", Value: applicationID.String()})
+		h.UpdateStatus(c)
+		return w
+	}
+
+	t.Run("should approve and recount openings in one transaction", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestApplicationHandler(db, testConfig())
+		job := openJob()
+		applicationID := uuid.New()
+
+		expectFindApplication(mock, applicationID, job, models.ApplicationStatusReviewing)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+			WithArgs(job.ID).
+			WillReturnRows(jobRow(job))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "applications"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET "filled"=$1`)).
+			WithArgs(1, job.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		w := updateStatus(t, h, applicationID, models.ApplicationStatusApproved)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.ApplicationResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.ApplicationStatusApproved, resp.Status)
+		require.NotNil(t, resp.Job)
+		assert.Equal(t, 1, resp.Job.Filled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should keep the old status when the recount fails", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestApplicationHandler(db, testConfig())
+		job := openJob()
+		applicationID := uuid.New()
+
+		expectFindApplication(mock, applicationID, job, models.ApplicationStatusApproved)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+			WillReturnRows(jobRow(job))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "applications"`)).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		w := updateStatus(t, h, applicationID, models.ApplicationStatusRejected)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not recount openings for other changes", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestApplicationHandler(db, testConfig())
+		job := openJob()
+		applicationID := uuid.New()
+
+		expectFindApplication(mock, applicationID, job, models.ApplicationStatusPending)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		w := updateStatus(t, h, applicationID, models.ApplicationStatusReviewing)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestApplicationHandler_ApplicationSource(t *testing.T) {
+	cfg := testConfig()
+	candidateID := uuid.New()
+	jobA, jobB := uuid.New(), uuid.New()
+
+	withCookies := func(t *testing.T, cookies ...*http.Cookie) *gin.Context {
+		c, _ := newRequest(t, http.MethodPost, "/api/applications", nil, candidateID, models.RoleCandidate)
+		for _, cookie := range cookies {
+			c.Request.AddCookie(cookie)
+		}
+		return c
+	}
+	cookieFor := func(name, jobID uuid.UUID, channel string) *http.Cookie {
+		return &http.Cookie{
+			Name:  sourceCookieName(name),
+			Value: analytics.EncodeSource(jobID, models.ApplicationSource{Channel: channel}, cfg.JWT.Secret),
+		}
+	}
+
+	t.Run("should credit the source captured for the job applied to", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestApplicationHandler(db, cfg)
+		c := withCookies(t, cookieFor(jobA, jobA, "linkedin"), cookieFor(jobB, jobB, "indeed"))
+
+		source, err := h.applicationSource(c, jobA, candidateID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "linkedin", source.Channel)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should ignore a source captured for another job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestApplicationHandler(db, cfg)
+		c := withCookies(t, cookieFor(jobA, jobB, "indeed"))
+
+		source, err := h.applicationSource(c, jobA, candidateID)
+
+		require.NoError(t, err)
+		assert.True(t, source.IsDirect())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+type BookmarkHandler struct {
+	bookmarkRepo *repository.BookmarkRepository
+	jobRepo      *repository.JobRepository
+	cfg          *config.Config
+}
+
+func NewBookmarkHandler(
+	bookmarkRepo *repository.BookmarkRepository,
+	jobRepo *repository.JobRepository,
+	cfg *config.Config,
+) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkRepo: bookmarkRepo,
+		jobRepo:      jobRepo,
+		cfg:          cfg,
+	}
+}
+
+// Add godoc
+// @Summary      Salvar vaga nos favoritos
+// @Description  Adiciona uma vaga publicada aos favoritos do candidato. Salvar novamente não tem efeito.
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      201 {object} models.BookmarkResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/bookmark [post]
+func (h *BookmarkHandler) Add(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	now := time.Now()
+	if !job.IsPubliclyVisible(now) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	job.ApplySchedule(now)
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	bookmark := &models.Bookmark{
+		CandidateID: claims.UserID,
+		JobID:       job.ID,
+		CreatedAt:   now,
+	}
+	if err := h.bookmarkRepo.Add(bookmark); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to bookmark job"})
+		return
+	}
+	bookmark.Job = *job
+
+	c.JSON(http.StatusCreated, bookmark.ToResponse(now, h.cfg.Jobs.BookmarkClosingNotice))
+}
+
+// Remove godoc
+// @Summary      Remover vaga dos favoritos
+// @Description  Remove uma vaga dos favoritos do candidato
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/bookmark [delete]
+func (h *BookmarkHandler) Remove(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	removed, err := h.bookmarkRepo.Remove(claims.UserID, jobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove bookmark"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bookmark not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bookmark removed successfully"})
+}
+
+// List godoc
+// @Summary      Listar vagas favoritas
+// @Description  Lista as vagas salvas pelo candidato autenticado, indicando as que estão prestes a encerrar
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.BookmarkResponse
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/bookmarks [get]
+func (h *BookmarkHandler) List(c *gin.Context) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	bookmarks, err := h.bookmarkRepo.FindByCandidateID(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list bookmarks"})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]models.BookmarkResponse, len(bookmarks))
+	for i := range bookmarks {
+		bookmarks[i].Job.ApplySchedule(now)
+		responses[i] = bookmarks[i].ToResponse(now, h.cfg.Jobs.BookmarkClosingNotice)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
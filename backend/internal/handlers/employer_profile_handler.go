@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type EmployerProfileHandler struct {
+	profileRepo     *repository.EmployerProfileRepository
+	jobRepo         *repository.JobRepository
+	translationRepo *repository.JobTranslationRepository
+}
+
+type SaveEmployerProfileRequest struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Slug    string `json:"slug"`
+	LogoURL string `json:"logo_url" binding:"omitempty,http_url,max=2048"`
+	About   string `json:"about"`
+}
+
+func NewEmployerProfileHandler(
+	profileRepo *repository.EmployerProfileRepository,
+	jobRepo *repository.JobRepository,
+	translationRepo *repository.JobTranslationRepository,
+) *EmployerProfileHandler {
+	return &EmployerProfileHandler{
+		profileRepo:     profileRepo,
+		jobRepo:         jobRepo,
+		translationRepo: translationRepo,
+	}
+}
+
+// Get godoc
+// @Summary      Obter perfil de empregador
+// @Description  Retorna o perfil de empregador do admin autenticado, usado na página de carreiras
+// @Tags         employer-profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.EmployerProfileResponse
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /employer-profile [get]
+func (h *EmployerProfileHandler) Get(c *gin.Context) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	profile, err := h.profileRepo.FindByUserID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Employer profile not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get employer profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile.ToResponse())
+}
+
+// Save godoc
+// @Summary      Salvar perfil de empregador
+// @Description  Cria ou atualiza o perfil de empregador do admin autenticado: nome, logo, texto de apresentação (Markdown, devolvido também como HTML sanitizado em `about_html`) e slug da página de carreiras. Sem slug, ele é gerado a partir do nome na criação e mantido nas atualizações.
+// @Tags         employer-profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body SaveEmployerProfileRequest true "Dados do perfil"
+// @Success      200 {object} models.EmployerProfileResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /employer-profile [put]
+func (h *EmployerProfileHandler) Save(c *gin.Context) {
+	var req SaveEmployerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	profile, err := h.profileRepo.FindByUserID(claims.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get employer profile"})
+		return
+	}
+	if profile == nil {
+		profile = &models.EmployerProfile{UserID: claims.UserID}
+	}
+
+	slug := req.Slug
+	if slug == "" {
+		slug = profile.Slug
+	}
+	if slug == "" {
+		slug = utils.Slugify(req.Name)
+	}
+	if !utils.IsSlug(slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slug must be lowercase letters and digits separated by single hyphens"})
+		return
+	}
+	if slug != profile.Slug {
+		available, err := h.profileRepo.SlugAvailable(slug, claims.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check slug"})
+			return
+		}
+		if !available {
+			c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use"})
+			return
+		}
+	}
+
+	profile.Name = req.Name
+	profile.Slug = slug
+	profile.LogoURL = req.LogoURL
+	profile.About = req.About
+
+	if err := h.profileRepo.Save(profile); err != nil {
+		if errors.Is(err, repository.ErrSlugTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save employer profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile.ToResponse())
+}
+
+// Careers godoc
+// @Summary      Página de carreiras
+// @Description  Retorna o perfil de empregador e as vagas abertas publicadas pelo recrutador dono do perfil, mais recentes primeiro, para uma página de carreiras pública ou incorporada em outro site (CORS liberado para qualquer origem). As vagas seguem o idioma de `?lang=` ou do Accept-Language.
+// @Tags         careers
+// @Accept       json
+// @Produce      json
+// @Param        slug path string true "Slug do perfil de empregador"
+// @Param        page query int false "Página"
+// @Param        limit query int false "Itens por página"
+// @Param        lang query string false "Idioma das vagas (pt-BR, es, en); quando ausente, usa o Accept-Language"
+// @Success      200 {object} map[string]interface{}
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /careers/{slug} [get]
+func (h *EmployerProfileHandler) Careers(c *gin.Context) {
+	profile, err := h.profileRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Careers page not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get employer profile"})
+		return
+	}
+
+	now := time.Now()
+	filters := repository.JobFilters{
+		Status:      string(models.JobStatusOpen),
+		RecruiterID: &profile.UserID,
+		VisibleAt:   &now,
+		SortBy:      "published_at",
+		Order:       "DESC",
+	}
+	if val, err := strconv.Atoi(c.Query("page")); err == nil {
+		filters.Page = val
+	}
+	if val, err := strconv.Atoi(c.Query("limit")); err == nil {
+		filters.Limit = val
+	}
+
+	jobs, total, err := h.jobRepo.FindAll(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
+		return
+	}
+
+	responses := make([]models.JobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = job.ToResponse(false)
+	}
+	if err := translateJobs(c, h.translationRepo, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"employer": profile.ToResponse(),
+		"jobs":     responses,
+		"total":    total,
+		"page":     filters.Page,
+		"limit":    filters.Limit,
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/feeds"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"gorm.io/gorm"
+)
+
+// maxFeedJobs bounds the jobs listed in each feed, most recent first.
+const maxFeedJobs = 1000
+
+type FeedHandler struct {
+	jobRepo *repository.JobRepository
+	source  feeds.Source
+}
+
+func NewFeedHandler(jobRepo *repository.JobRepository, cfg *config.Config) *FeedHandler {
+	return &FeedHandler{
+		jobRepo: jobRepo,
+		source:  feeds.Source{BaseURL: cfg.Server.BaseURL, Org: cfg.Org},
+	}
+}
+
+// RSS serves the open jobs as an RSS 2.0 channel. Feeds live outside /api
+// so aggregators get stable, unversioned URLs.
+func (h *FeedHandler) RSS(c *gin.Context) {
+	h.render(c, "application/rss+xml; charset=utf-8", func(jobs []models.Job, now time.Time) ([]byte, error) {
+		return feeds.RSS(h.source, jobs, h.source.BaseURL+"/feeds/jobs.rss", now)
+	})
+}
+
+// Atom serves the open jobs as an Atom 1.0 feed.
+func (h *FeedHandler) Atom(c *gin.Context) {
+	h.render(c, "application/atom+xml; charset=utf-8", func(jobs []models.Job, now time.Time) ([]byte, error) {
+		return feeds.Atom(h.source, jobs, h.source.BaseURL+"/feeds/jobs.atom", now)
+	})
+}
+
+// JobBoard serves the open jobs in the XML format job boards and
+// aggregators ingest.
+func (h *FeedHandler) JobBoard(c *gin.Context) {
+	h.render(c, "application/xml; charset=utf-8", func(jobs []models.Job, now time.Time) ([]byte, error) {
+		return feeds.JobBoard(h.source, jobs, now)
+	})
+}
+
+// JSONLD godoc
+// @Summary      Dados estruturados da vaga
+// @Description  Retorna o JSON-LD schema.org JobPosting de uma vaga aberta, para incorporar na página da vaga
+// @Tags         feeds
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Success      200 {object} feeds.JobPosting
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/jsonld [get]
+func (h *FeedHandler) JSONLD(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	// Search engines must only index jobs that still accept applications.
+	if !job.AcceptsApplications(time.Now()) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	c.Header("Content-Type", "application/ld+json; charset=utf-8")
+	c.JSON(http.StatusOK, feeds.NewJobPosting(h.source, job))
+}
+
+// render lists the open jobs and writes the feed built from them.
+func (h *FeedHandler) render(c *gin.Context, contentType string, build func([]models.Job, time.Time) ([]byte, error)) {
+	now := time.Now()
+	jobs, _, err := h.jobRepo.FindAll(repository.JobFilters{
+		Status:    string(models.JobStatusOpen),
+		VisibleAt: &now,
+		SortBy:    "published_at",
+		Order:     "DESC",
+		Limit:     maxFeedJobs,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
+		return
+	}
+
+	body, err := build(jobs, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build feed"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, contentType, body)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/compliance"
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/lint"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return db, mock
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		JWT: config.JWTConfig{Secret: "test-secret"},
+		Jobs: config.JobsConfig{
+			AllowSelfApproval:     true,
+			BookmarkClosingNotice: 48 * time.Hour,
+			ClosingSoonWindow:     72 * time.Hour,
+		},
+	}
+}
+
+func newTestJobHandler(t *testing.T, db *gorm.DB, cfg *config.Config) *JobHandler {
+	linter, err := lint.Load()
+	require.NoError(t, err)
+
+	return NewJobHandler(
+		repository.NewJobRepository(db),
+		repository.NewJobReviewRepository(db),
+		repository.NewJobTemplateRepository(db),
+		repository.NewJobQuestionRepository(db),
+		repository.NewKnockoutRuleRepository(db),
+		repository.NewSkillRepository(db),
+		repository.NewBookmarkRepository(db),
+		repository.NewJobTranslationRepository(db),
+		repository.NewUserRepository(db),
+		analytics.NewViewTracker(repository.NewJobViewRepository(db)),
+		compliance.Default(),
+		linter,
+		NewJobAccess(repository.NewJobTeamRepository(db)),
+		cfg,
+	)
+}
+
+func newTestApplicationHandler(db *gorm.DB, cfg *config.Config) *ApplicationHandler {
+	return NewApplicationHandler(
+		repository.NewApplicationRepository(db),
+		repository.NewJobRepository(db),
+		repository.NewKnockoutRuleRepository(db),
+		repository.NewUserRepository(db),
+		NewJobAccess(repository.NewJobTeamRepository(db)),
+		cfg,
+	)
+}
+
+// newRequest builds a gin context for the request, authenticated as userID
+// with role when userID is not nil, and with the route's path parameters.
+func newRequest(t *testing.T, method, path string, body interface{}, userID uuid.UUID, role models.UserRole, params ...gin.Param) (*gin.Context, *httptest.ResponseRecorder) {
+	c, w := testutil.SetupGinTestContext(t, method, path, body)
+	if userID != uuid.Nil {
+		c.Set(middleware.UserContextKey, &jwt.Claims{UserID: userID, Role: role})
+	}
+	c.Params = params
+	return c, w
+}
+
+var jobColumns = []string{"id", "title", "description", "location", "type", "status", "recruiter_id", "openings", "published_at", "expires_at", "application_deadline", "slug"}
+
+// jobRow returns the row FindByID scans for job.
+func jobRow(job *models.Job) *sqlmock.Rows {
+	return sqlmock.NewRows(jobColumns).AddRow(
+		job.ID, job.Title, job.Description, job.Location, job.Type, job.Status, job.RecruiterID,
+		job.Openings, job.PublishedAt, job.ExpiresAt, job.ApplicationDeadline, job.Slug,
+	)
+}
+
+// expectFindJob expects JobRepository.FindByID to load job with no
+// questions or skills.
+func expectFindJob(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+		WithArgs(job.ID).
+		WillReturnRows(jobRow(job))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_questions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(job.RecruiterID, models.RoleAdmin))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_skills"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"job_id", "skill_id"}))
+}
+
+// expectUpdateJob expects JobRepository.Update to save the job and record a
+// new revision.
+func expectUpdateJob(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecordRevision(mock)
+	mock.ExpectCommit()
+}
+
+// expectRecordRevision expects a first revision to be recorded under the
+// job's row lock.
+func expectRecordRevision(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE id = $1 LIMIT 1 FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_revisions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_revisions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+// JobAccess decides what a user may do on a job from the job's hiring team.
+// It is shared by every handler that acts on a job on behalf of its team.
+type JobAccess struct {
+	teamRepo *repository.JobTeamRepository
+}
+
+func NewJobAccess(teamRepo *repository.JobTeamRepository) *JobAccess {
+	return &JobAccess{teamRepo: teamRepo}
+}
+
+// RoleOf returns the user's role on the job's hiring team. The job's creator
+// is always an owner; ok is false for users outside the team.
+func (a *JobAccess) RoleOf(job *models.Job, userID uuid.UUID) (role models.TeamRole, ok bool, err error) {
+	if job.RecruiterID == userID {
+		return models.TeamRoleOwner, true, nil
+	}
+
+	member, err := a.teamRepo.Find(job.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return member.Role, true, nil
+}
+
+// Can reports whether the user has the capability on the job.
+func (a *JobAccess) Can(job *models.Job, userID uuid.UUID, capability models.JobCapability) (bool, error) {
+	role, ok, err := a.RoleOf(job, userID)
+	if err != nil || !ok {
+		return false, err
+	}
+	return role.Can(capability), nil
+}
+
+// Authorize checks that the authenticated user has the capability on the
+// job, writing the error response itself when it returns false.
+func (a *JobAccess) Authorize(c *gin.Context, job *models.Job, capability models.JobCapability, forbiddenMessage string) bool {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	allowed, err := a.Can(job, claims.UserID, capability)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check job permissions"})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": forbiddenMessage})
+		return false
+	}
+	return true
+}
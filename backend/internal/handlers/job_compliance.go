@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ledufranco/recruitment-system/internal/compliance"
+	"github.com/ledufranco/recruitment-system/internal/models"
+)
+
+// ComplianceErrorResponse lists why a job cannot be published.
+type ComplianceErrorResponse struct {
+	Error      string                 `json:"error"`
+	Violations []compliance.Violation `json:"violations"`
+}
+
+// checkCompliance evaluates job against the publishing policy and responds
+// 422 with the violations when there are any. Drafts are not checked, so a
+// job only has to comply once it heads for publication.
+func (h *JobHandler) checkCompliance(c *gin.Context, job *models.Job) bool {
+	// The location may have been edited since the job was loaded.
+	job.ResolveLocation()
+
+	violations := h.policy.Evaluate(job)
+	if len(violations) == 0 {
+		return true
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, ComplianceErrorResponse{
+		Error:      "Job does not comply with the publishing policy",
+		Violations: violations,
+	})
+	return false
+}
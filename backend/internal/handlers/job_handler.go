@@ -1,53 +1,264 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/compliance"
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/ledufranco/recruitment-system/internal/lint"
 	"github.com/ledufranco/recruitment-system/internal/middleware"
 	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/recommend"
 	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
 	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
 	"gorm.io/gorm"
 )
 
+const (
+	defaultSimilarJobs   = 5
+	maxSimilarJobs       = 20
+	similarCandidatePool = 500
+
+	// viewerCookie keeps an anonymous visitor's viewer key stable across
+	// requests so their views are deduplicated like a signed-in user's.
+	viewerCookie       = "job_viewer"
+	viewerCookieMaxAge = 365 * 24 * 60 * 60
+
+	// The source cookie of a job remembers where a visitor last came from
+	// to it, so the application they send later is credited to that source.
+	// Each job has its own, named sourceCookiePrefix plus the job ID.
+	sourceCookiePrefix = "application_source_"
+	sourceCookieMaxAge = 30 * 24 * 60 * 60
+)
+
 type JobHandler struct {
-	jobRepo *repository.JobRepository
+	jobRepo      *repository.JobRepository
+	reviewRepo   *repository.JobReviewRepository
+	templateRepo *repository.JobTemplateRepository
+	questionRepo *repository.JobQuestionRepository
+	ruleRepo     *repository.KnockoutRuleRepository
+	skillRepo    *repository.SkillRepository
+	bookmarkRepo *repository.BookmarkRepository
+	translations *repository.JobTranslationRepository
+	userRepo     *repository.UserRepository
+	views        *analytics.ViewTracker
+	policy       *compliance.Policy
+	linter       *lint.Linter
+	access       *JobAccess
+	cfg          *config.Config
 }
 
 type CreateJobRequest struct {
-	Title       string           `json:"title" binding:"required"`
-	Description string           `json:"description" binding:"required"`
-	Salary      *float64         `json:"salary"`
-	Location    string           `json:"location" binding:"required"`
-	Type        models.JobType   `json:"type" binding:"required,oneof=remote onsite hybrid"`
+	Title               string              `json:"title" binding:"required"`
+	Description         string              `json:"description" binding:"required"`
+	Salary              *float64            `json:"salary"` // Deprecated: use salary_min/salary_max
+	SalaryMin           *float64            `json:"salary_min" binding:"omitempty,gte=0"`
+	SalaryMax           *float64            `json:"salary_max" binding:"omitempty,gte=0"`
+	SalaryCurrency      string              `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod        models.SalaryPeriod `json:"salary_period" binding:"omitempty,oneof=hour month year"`
+	SalaryNegotiable    bool                `json:"salary_negotiable"`
+	Location            string              `json:"location" binding:"required"`
+	Type                models.JobType      `json:"type" binding:"required,oneof=remote onsite hybrid"`
+	ContractType        models.ContractType `json:"contract_type" binding:"omitempty,oneof=full_time part_time contractor temporary internship"`
+	Locale              models.Locale       `json:"locale" binding:"omitempty,oneof=pt-BR es en"`
+	Openings            int                 `json:"openings" binding:"omitempty,gte=1"`
+	AutoRejectOnFill    bool                `json:"auto_reject_on_fill"`
+	FillMessage         string              `json:"fill_message"`
+	PublishAt           *time.Time          `json:"publish_at"`
+	ExpiresAt           *time.Time          `json:"expires_at"`
+	ApplicationDeadline *time.Time          `json:"application_deadline"`
+}
+
+// NewJob builds the draft job described by the request and checks the rules
+// binding tags cannot express.
+func (req *CreateJobRequest) NewJob(recruiterID uuid.UUID, now time.Time) (*models.Job, error) {
+	job := &models.Job{
+		RecruiterID:         recruiterID,
+		Title:               req.Title,
+		Description:         req.Description,
+		SalaryMin:           req.SalaryMin,
+		SalaryMax:           req.SalaryMax,
+		SalaryCurrency:      strings.ToUpper(req.SalaryCurrency),
+		SalaryPeriod:        req.SalaryPeriod,
+		SalaryNegotiable:    req.SalaryNegotiable,
+		Location:            req.Location,
+		Type:                req.Type,
+		ContractType:        req.ContractType,
+		Locale:              req.Locale,
+		Status:              models.JobStatusDraft,
+		Openings:            req.Openings,
+		AutoRejectOnFill:    req.AutoRejectOnFill,
+		FillMessage:         strings.TrimSpace(req.FillMessage),
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
+	}
+	if req.Salary != nil && req.SalaryMin == nil && req.SalaryMax == nil {
+		job.SalaryMin, job.SalaryMax = req.Salary, req.Salary
+	}
+
+	if err := validateNewJob(job, now); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// validateNewJob checks the salary and schedule of a job about to be
+// created, which must not already be expired or past its deadline.
+func validateNewJob(job *models.Job, now time.Time) error {
+	if err := job.ValidateSalary(); err != nil {
+		return err
+	}
+	if err := job.ValidateSchedule(); err != nil {
+		return err
+	}
+	if job.ExpiresAt != nil && !job.ExpiresAt.After(now) {
+		return errors.New("expires_at must be in the future")
+	}
+	if job.DeadlinePassed(now) {
+		return errors.New("application_deadline must be in the future")
+	}
+	return nil
+}
+
+// JobContentRequest holds the optional posting fields shared by job updates,
+// template edits and template/clone overrides. Empty fields are left as is.
+type JobContentRequest struct {
+	Title            string              `json:"title"`
+	Description      string              `json:"description"`
+	Salary           *float64            `json:"salary"` // Deprecated: use salary_min/salary_max
+	SalaryMin        *float64            `json:"salary_min" binding:"omitempty,gte=0"`
+	SalaryMax        *float64            `json:"salary_max" binding:"omitempty,gte=0"`
+	SalaryCurrency   string              `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod     models.SalaryPeriod `json:"salary_period" binding:"omitempty,oneof=hour month year"`
+	SalaryNegotiable *bool               `json:"salary_negotiable"`
+	Location         string              `json:"location"`
+	Type             models.JobType      `json:"type" binding:"omitempty,oneof=remote onsite hybrid"`
+	ContractType     models.ContractType `json:"contract_type" binding:"omitempty,oneof=full_time part_time contractor temporary internship"`
+	Locale           models.Locale       `json:"locale" binding:"omitempty,oneof=pt-BR es en"`
+	Openings         int                 `json:"openings" binding:"omitempty,gte=1"`
+	AutoRejectOnFill *bool               `json:"auto_reject_on_fill"`
+	FillMessage      string              `json:"fill_message"`
 }
 
 type UpdateJobRequest struct {
-	Title       string           `json:"title"`
-	Description string           `json:"description"`
-	Salary      *float64         `json:"salary"`
-	Location    string           `json:"location"`
-	Type        models.JobType   `json:"type" binding:"omitempty,oneof=remote onsite hybrid"`
-	Status      models.JobStatus `json:"status" binding:"omitempty,oneof=open closed archived"`
+	JobContentRequest
+	Status              models.JobStatus `json:"status" binding:"omitempty,oneof=open closed archived"`
+	PublishAt           *time.Time       `json:"publish_at"`
+	ExpiresAt           *time.Time       `json:"expires_at"`
+	ApplicationDeadline *time.Time       `json:"application_deadline"`
+	Slug                *string          `json:"slug"`
+}
+
+// JobOverridesRequest customizes a job created from a template or a clone.
+type JobOverridesRequest struct {
+	JobContentRequest
+	PublishAt           *time.Time `json:"publish_at"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	ApplicationDeadline *time.Time `json:"application_deadline"`
+}
+
+type ExtendDeadlineRequest struct {
+	ApplicationDeadline time.Time `json:"application_deadline" binding:"required"`
+}
+
+type ReviewJobRequest struct {
+	Comment string `json:"comment"`
+}
+
+type RejectJobRequest struct {
+	Comment string `json:"comment" binding:"required"`
+}
+
+type JobQuestionRequest struct {
+	Prompt   string              `json:"prompt" binding:"required"`
+	Type     models.QuestionType `json:"type" binding:"required,oneof=yes_no single_choice multi_choice number text"`
+	Options  []string            `json:"options"`
+	Required bool                `json:"required"`
+}
+
+type SetJobQuestionsRequest struct {
+	Questions []JobQuestionRequest `json:"questions" binding:"dive"`
+}
+
+type KnockoutRuleRequest struct {
+	QuestionID       uuid.UUID               `json:"question_id" binding:"required"`
+	Operator         models.KnockoutOperator `json:"operator" binding:"required,oneof=equals not_equals gte lte includes excludes"`
+	Value            json.RawMessage         `json:"value" binding:"required" swaggertype:"object"`
+	Action           models.KnockoutAction   `json:"action" binding:"required,oneof=reject flag"`
+	Message          string                  `json:"message"`
+	NoticeDelayHours int                     `json:"notice_delay_hours" binding:"gte=0"`
+}
+
+type SetKnockoutRulesRequest struct {
+	Rules []KnockoutRuleRequest `json:"rules" binding:"dive"`
+}
+
+type JobSkillRequest struct {
+	Name  string            `json:"name" binding:"required"`
+	Level models.SkillLevel `json:"level" binding:"omitempty,oneof=required nice_to_have"`
+}
+
+type SetJobSkillsRequest struct {
+	Skills []JobSkillRequest `json:"skills" binding:"dive"`
 }
 
-func NewJobHandler(jobRepo *repository.JobRepository) *JobHandler {
-	return &JobHandler{jobRepo: jobRepo}
+func NewJobHandler(
+	jobRepo *repository.JobRepository,
+	reviewRepo *repository.JobReviewRepository,
+	templateRepo *repository.JobTemplateRepository,
+	questionRepo *repository.JobQuestionRepository,
+	ruleRepo *repository.KnockoutRuleRepository,
+	skillRepo *repository.SkillRepository,
+	bookmarkRepo *repository.BookmarkRepository,
+	translations *repository.JobTranslationRepository,
+	userRepo *repository.UserRepository,
+	views *analytics.ViewTracker,
+	policy *compliance.Policy,
+	linter *lint.Linter,
+	access *JobAccess,
+	cfg *config.Config,
+) *JobHandler {
+	return &JobHandler{
+		jobRepo:      jobRepo,
+		reviewRepo:   reviewRepo,
+		templateRepo: templateRepo,
+		questionRepo: questionRepo,
+		ruleRepo:     ruleRepo,
+		skillRepo:    skillRepo,
+		bookmarkRepo: bookmarkRepo,
+		translations: translations,
+		userRepo:     userRepo,
+		views:        views,
+		policy:       policy,
+		linter:       linter,
+		access:       access,
+		cfg:          cfg,
+	}
 }
 
 // Create godoc
 // @Summary      Criar nova vaga
-// @Description  Cria uma nova vaga de emprego (apenas admin)
+// @Description  Cria uma nova vaga como rascunho (apenas admin). A vaga só é publicada após envio para aprovação e aprovação. A descrição aceita Markdown e é devolvida também como HTML sanitizado em `description_html`. Trechos com linguagem não inclusiva são apontados em `lint`, com sugestões de alternativas.
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request body CreateJobRequest true "Dados da vaga"
-// @Success      201 {object} models.JobResponse
+// @Success      201 {object} LintedJobResponse
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      403 {object} map[string]string
@@ -63,43 +274,131 @@ func (h *JobHandler) Create(c *gin.Context) {
 	userClaims, _ := c.Get(middleware.UserContextKey)
 	claims := userClaims.(*jwt.Claims)
 
-	job := &models.Job{
-		RecruiterID: claims.UserID,
-		Title:       req.Title,
-		Description: req.Description,
-		Salary:      req.Salary,
-		Location:    req.Location,
-		Type:        req.Type,
-		Status:      models.JobStatusOpen,
+	job, err := req.NewJob(claims.UserID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	if err := h.jobRepo.Create(job); err != nil {
+	if err := h.jobRepo.Create(job, &claims.UserID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, job.ToResponse(false))
+	c.JSON(http.StatusCreated, h.lintedResponse(job))
 }
 
 // GetByID godoc
 // @Summary      Obter vaga por ID
-// @Description  Retorna os detalhes de uma vaga específica
+// @Description  Retorna os detalhes de uma vaga específica. Vagas não publicadas só são visíveis para admins. Cada visualização de candidato ou visitante é contabilizada uma vez por dia nas estatísticas da vaga. A origem da visita (ref, parâmetros UTM, Referer e funcionário que indicou) fica no cookie application_source_<id da vaga> e é atribuída à candidatura enviada depois para a mesma vaga.
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "Job ID"
+// @Security     BearerAuth
+// @Param        id path string true "Job ID ou slug"
+// @Param        ref query string false "Origem da visita (ex.: linkedin, newsletter); quando ausente, usa o domínio do Referer"
+// @Param        utm_source query string false "Origem da campanha"
+// @Param        utm_medium query string false "Mídia da campanha"
+// @Param        utm_campaign query string false "Nome da campanha"
+// @Param        referred_by query string false "ID do funcionário que indicou o candidato"
+// @Param        lang query string false "Idioma da vaga (pt-BR, es, en); quando ausente, usa o Accept-Language"
+// @Param        Accept-Language header string false "Idiomas preferidos"
 // @Success      200 {object} models.JobResponse
+// @Success      301 "Slug antigo: redireciona para o slug atual"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /jobs/{id} [get]
 func (h *JobHandler) GetByID(c *gin.Context) {
+	param := c.Param("id")
+	var job *models.Job
+	var err error
+	if id, parseErr := uuid.Parse(param); parseErr == nil {
+		job, err = h.jobRepo.FindByID(id)
+	} else if utils.IsSlug(param) {
+		job, err = h.jobRepo.FindBySlug(param)
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID or slug"})
+		return
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	now := time.Now()
+	if !job.IsPubliclyVisible(now) && !isAdmin(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	// Links with a slug the job no longer uses keep working.
+	if _, parseErr := uuid.Parse(param); parseErr != nil && param != job.Slug {
+		location := path.Join(path.Dir(c.Request.URL.Path), job.Slug)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	job.ApplySchedule(now)
+
+	responses := []models.JobResponse{job.ToResponse(true)}
+	if err := h.markBookmarks(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get bookmarks"})
+		return
+	}
+	if err := h.translate(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+	c.Header("Content-Language", string(responses[0].Locale))
+
+	// Recruiters previewing postings would inflate the funnel.
+	if !isAdmin(c) {
+		h.trackView(c, job.ID, now)
+		h.rememberSource(c, job.ID)
+	}
+
+	c.JSON(http.StatusOK, responses[0])
+}
+
+// Similar godoc
+// @Summary      Vagas semelhantes
+// @Description  Lista outras vagas abertas semelhantes à vaga informada, considerando título, descrição, tipo, localização e salário. `similarity` vai de 0 a 1.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Param        limit query integer false "Quantidade de vagas (máximo 20)" default(5)
+// @Param        lang query string false "Idioma das vagas (pt-BR, es, en); quando ausente, usa o Accept-Language"
+// @Success      200 {array} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/similar [get]
+func (h *JobHandler) Similar(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
 		return
 	}
 
+	limit := defaultSimilarJobs
+	if limitStr := c.Query("limit"); limitStr != "" {
+		val, err := strconv.Atoi(limitStr)
+		if err != nil || val < 1 || val > maxSimilarJobs {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxSimilarJobs)})
+			return
+		}
+		limit = val
+	}
+
 	job, err := h.jobRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -110,7 +409,44 @@ func (h *JobHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, job.ToResponse(true))
+	now := time.Now()
+	if !job.IsPubliclyVisible(now) && !isAdmin(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	// Rank among the most recently published open jobs to bound the work
+	// done per request.
+	candidates, _, err := h.jobRepo.FindAll(repository.JobFilters{
+		Status:    string(models.JobStatusOpen),
+		VisibleAt: &now,
+		SortBy:    "published_at",
+		Order:     "DESC",
+		Limit:     similarCandidatePool,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
+		return
+	}
+
+	matches := recommend.Similar(*job, candidates, limit)
+
+	responses := make([]models.JobResponse, len(matches))
+	for i, match := range matches {
+		score := math.Round(match.Score*1000) / 1000
+		responses[i] = match.Job.ToResponse(true)
+		responses[i].Similarity = &score
+	}
+	if err := h.markBookmarks(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get bookmarks"})
+		return
+	}
+	if err := h.translate(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses)
 }
 
 // List godoc
@@ -119,38 +455,73 @@ func (h *JobHandler) GetByID(c *gin.Context) {
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
-// @Param        search query string false "Buscar por título ou descrição"
+// @Param        search query string false "Buscar por título ou descrição, em qualquer idioma da vaga"
 // @Param        location query string false "Filtrar por localização"
 // @Param        type query string false "Filtrar por tipo (remote, onsite, hybrid)"
-// @Param        status query string false "Filtrar por status (open, closed, archived)" default(open)
-// @Param        salary_min query number false "Salário mínimo"
-// @Param        salary_max query number false "Salário máximo"
+// @Param        status query string false "Filtrar por status publicado (open, closed, archived)" default(open)
+// @Param        salary_min query number false "Salário mínimo (no período de salary_period)"
+// @Param        salary_max query number false "Salário máximo (no período de salary_period)"
+// @Param        salary_period query string false "Período dos filtros de salário (hour, month, year)" default(month)
+// @Param        salary_currency query string false "Filtrar por moeda (ISO 4217)"
+// @Param        skills query string false "Habilidades separadas por vírgula (nomes ou apelidos)"
+// @Param        skills_match query string false "Combinação das habilidades (any, all)" default(any)
+// @Param        near query string false "Cidade de referência para busca por raio (ex.: Niterói, RJ)"
+// @Param        radius_km query number false "Raio em km a partir de near (vagas remotas não são afetadas)" default(50)
 // @Param        page query integer false "Número da página" default(1)
 // @Param        limit query integer false "Itens por página" default(10)
-// @Param        sort_by query string false "Campo para ordenação" default(created_at)
+// @Param        closing_soon query boolean false "Apenas vagas cujas candidaturas encerram em breve (prazo ou expiração dentro de JOB_CLOSING_SOON_WINDOW)"
+// @Param        sort_by query string false "Campo para ordenação (created_at, updated_at, published_at, title, salary, distance, closing)" default(created_at)
 // @Param        order query string false "Ordem (ASC, DESC)" default(DESC)
+// @Param        lang query string false "Idioma das vagas (pt-BR, es, en); quando ausente, usa o Accept-Language"
+// @Param        Accept-Language header string false "Idiomas preferidos"
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]string
+// @Failure      422 {object} map[string]string "Busca por raio não suportada para a cidade de near"
 // @Failure      500 {object} map[string]string
 // @Router       /jobs [get]
 func (h *JobHandler) List(c *gin.Context) {
-	filters := repository.JobFilters{
-		Search:   c.Query("search"),
-		Location: c.Query("location"),
-		Type:     c.Query("type"),
-		Status:   c.DefaultQuery("status", "open"),
-		SortBy:   c.DefaultQuery("sort_by", "created_at"),
-		Order:    c.DefaultQuery("order", "DESC"),
+	criteria, ok := searchCriteriaFromQuery(c)
+	if !ok {
+		return
 	}
 
-	if salaryMinStr := c.Query("salary_min"); salaryMinStr != "" {
-		if val, err := strconv.ParseFloat(salaryMinStr, 64); err == nil {
-			filters.SalaryMin = &val
-		}
+	status := c.DefaultQuery("status", "open")
+	if !models.JobStatus(status).IsPublished() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of open, closed, archived"})
+		return
 	}
 
-	if salaryMaxStr := c.Query("salary_max"); salaryMaxStr != "" {
-		if val, err := strconv.ParseFloat(salaryMaxStr, 64); err == nil {
-			filters.SalaryMax = &val
+	sortBy := c.DefaultQuery("sort_by", "created_at")
+	if sortBy == "distance" && criteria.Near == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort_by=distance requires near"})
+		return
+	}
+
+	filters, matchable, err := repository.BuildJobFilters(criteria, h.skillRepo)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve skills"})
+		return
+	}
+
+	filters.Status = status
+	filters.SortBy = sortBy
+	filters.Order = c.DefaultQuery("order", "DESC")
+	if sortBy == "distance" && c.Query("order") == "" {
+		filters.Order = "ASC"
+	}
+
+	now := time.Now()
+	filters.VisibleAt = &now
+
+	if closingSoon := c.Query("closing_soon"); closingSoon != "" {
+		val, err := strconv.ParseBool(closingSoon)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "closing_soon must be true or false"})
+			return
+		}
+		if val {
+			until := now.Add(h.cfg.Jobs.ClosingSoonWindow)
+			filters.ClosingAfter, filters.ClosingBefore = &now, &until
 		}
 	}
 
@@ -166,6 +537,18 @@ func (h *JobHandler) List(c *gin.Context) {
 		}
 	}
 
+	// No job can match an unknown skill, so "all" with one, or "any" with
+	// nothing but unknown skills, is an empty result.
+	if !matchable {
+		c.JSON(http.StatusOK, gin.H{
+			"jobs":  []models.JobResponse{},
+			"total": 0,
+			"page":  filters.Page,
+			"limit": filters.Limit,
+		})
+		return
+	}
+
 	jobs, total, err := h.jobRepo.FindAll(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
@@ -175,6 +558,17 @@ func (h *JobHandler) List(c *gin.Context) {
 	responses := make([]models.JobResponse, len(jobs))
 	for i, job := range jobs {
 		responses[i] = job.ToResponse(true)
+		if filters.Near != nil {
+			responses[i].DistanceKm = job.DistanceFrom(*filters.Near)
+		}
+	}
+	if err := h.markBookmarks(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get bookmarks"})
+		return
+	}
+	if err := h.translate(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -185,9 +579,69 @@ func (h *JobHandler) List(c *gin.Context) {
 	})
 }
 
+// searchCriteriaFromQuery reads the search parameters of the job listing,
+// responding with 400 when they are invalid.
+func searchCriteriaFromQuery(c *gin.Context) (models.SearchCriteria, bool) {
+	criteria := models.SearchCriteria{
+		Search:         c.Query("search"),
+		Location:       c.Query("location"),
+		Type:           models.JobType(c.Query("type")),
+		SalaryPeriod:   models.SalaryPeriod(c.DefaultQuery("salary_period", string(models.SalaryPeriodMonth))),
+		SalaryCurrency: c.Query("salary_currency"),
+		SkillsMatch:    c.DefaultQuery("skills_match", "any"),
+		Near:           c.Query("near"),
+	}
+
+	if skillsParam := c.Query("skills"); skillsParam != "" {
+		criteria.Skills = strings.Split(skillsParam, ",")
+	}
+
+	if salaryMinStr := c.Query("salary_min"); salaryMinStr != "" {
+		if val, err := strconv.ParseFloat(salaryMinStr, 64); err == nil {
+			criteria.SalaryMin = &val
+		}
+	}
+
+	if salaryMaxStr := c.Query("salary_max"); salaryMaxStr != "" {
+		if val, err := strconv.ParseFloat(salaryMaxStr, 64); err == nil {
+			criteria.SalaryMax = &val
+		}
+	}
+
+	radiusErr := fmt.Sprintf("radius_km must be between 0 and %d", geo.MaxRadiusKm)
+	if radiusStr := c.Query("radius_km"); radiusStr != "" && criteria.Near != "" {
+		val, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || val <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": radiusErr})
+			return criteria, false
+		}
+		criteria.RadiusKm = val
+	}
+
+	if err := criteria.Validate(); err != nil {
+		if errors.Is(err, models.ErrUnsupportedLocation) {
+			unsupportedLocation(c, criteria.Near)
+			return criteria, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return criteria, false
+	}
+
+	return criteria, true
+}
+
+// unsupportedLocation answers a radius search around a place missing from
+// the gazetteer. The request itself is valid, so it is not a 400.
+func unsupportedLocation(c *gin.Context, near string) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error": "Radius search is not supported for this location",
+		"near":  near,
+	})
+}
+
 // GetMyJobs godoc
 // @Summary      Obter minhas vagas
-// @Description  Retorna todas as vagas criadas pelo admin autenticado
+// @Description  Retorna as vagas criadas pelo admin autenticado ou em cuja equipe de contratação ele está
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
@@ -201,7 +655,7 @@ func (h *JobHandler) GetMyJobs(c *gin.Context) {
 	userClaims, _ := c.Get(middleware.UserContextKey)
 	claims := userClaims.(*jwt.Claims)
 
-	jobs, err := h.jobRepo.FindByRecruiterID(claims.UserID)
+	jobs, err := h.jobRepo.FindByTeamMember(claims.UserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get jobs"})
 		return
@@ -217,18 +671,20 @@ func (h *JobHandler) GetMyJobs(c *gin.Context) {
 
 // Update godoc
 // @Summary      Atualizar vaga
-// @Description  Atualiza uma vaga de emprego (apenas o admin que criou)
+// @Description  Atualiza uma vaga de emprego (owner ou recruiter da equipe). Vagas abertas ou agendadas precisam continuar em conformidade com a política de publicação (422 com as violações). Trechos com linguagem não inclusiva são apontados em `lint`. O slug não muda com o título; alterá-lo em `slug` mantém o antigo redirecionando para o novo (409 se já estiver em uso por outra vaga).
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path string true "Job ID"
 // @Param        request body UpdateJobRequest true "Dados para atualização"
-// @Success      200 {object} models.JobResponse
+// @Success      200 {object} LintedJobResponse
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      403 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      422 {object} ComplianceErrorResponse
 // @Failure      500 {object} map[string]string
 // @Router       /jobs/{id} [put]
 func (h *JobHandler) Update(c *gin.Context) {
@@ -257,41 +713,172 @@ func (h *JobHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if job.RecruiterID != claims.UserID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own jobs"})
+	if !h.access.Authorize(c, job, models.CapabilityEditJob, "You can only update jobs you recruit for") {
 		return
 	}
 
-	if req.Title != "" {
-		job.Title = req.Title
+	closeAt := job.ApplicationsCloseAt()
+	applyJobContent(job, req.JobContentRequest)
+	if req.Status != "" && req.Status != job.Status {
+		// Publication only goes through submit and approve, even where the
+		// state machine would allow it, as for pending_approval to open.
+		if !job.Status.CanTransitionTo(req.Status) || job.Status == models.JobStatusPendingApproval {
+			if job.Status == models.JobStatusDraft || job.Status == models.JobStatusPendingApproval {
+				c.JSON(http.StatusConflict, gin.H{"error": "Job must be submitted and approved before it can be published"})
+				return
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot change job status from " + string(job.Status) + " to " + string(req.Status)})
+			return
+		}
+		job.Status = req.Status
 	}
-	if req.Description != "" {
-		job.Description = req.Description
+	if req.PublishAt != nil {
+		job.PublishAt = req.PublishAt
 	}
-	if req.Salary != nil {
-		job.Salary = req.Salary
+	if req.ExpiresAt != nil {
+		job.ExpiresAt = req.ExpiresAt
 	}
-	if req.Location != "" {
-		job.Location = req.Location
+	if req.ApplicationDeadline != nil {
+		job.ApplicationDeadline = req.ApplicationDeadline
 	}
-	if req.Type != "" {
-		job.Type = req.Type
+
+	if err := job.ValidateSalary(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if req.Status != "" {
-		job.Status = req.Status
+	if err := job.ValidateSchedule(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slug := ""
+	if req.Slug != nil && *req.Slug != job.Slug {
+		slug = *req.Slug
+		if !utils.IsSlug(slug) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "slug must be lowercase letters and digits separated by single hyphens"})
+			return
+		}
+		available, err := h.jobRepo.SlugAvailable(slug, job.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check slug"})
+			return
+		}
+		if !available {
+			c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use"})
+			return
+		}
+	}
+
+	job.ApplySchedule(time.Now())
+
+	if job.Status == models.JobStatusOpen || job.Status == models.JobStatusScheduled {
+		if !h.checkCompliance(c, job) {
+			return
+		}
+	}
+
+	if slug != "" {
+		err = h.jobRepo.UpdateWithSlug(job, slug, &claims.UserID)
+	} else {
+		err = h.jobRepo.Update(job, &claims.UserID)
+	}
+	if errors.Is(err, repository.ErrSlugTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Slug is already in use"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+
+	if !sameTime(closeAt, job.ApplicationsCloseAt()) {
+		if err := h.bookmarkRepo.ResetClosingNotices(job.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update bookmarks"})
+			return
+		}
+	}
+
+	// Lowering openings, or reopening a filled job, may leave it filled.
+	if job.Status == models.JobStatusOpen {
+		synced, _, err := h.jobRepo.SyncOpenings(job.ID, h.cfg.Jobs.FilledRejectionMessage, &claims.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update openings"})
+			return
+		}
+		job.Status, job.Filled = synced.Status, synced.Filled
+	}
+
+	c.JSON(http.StatusOK, h.lintedResponse(job))
+}
+
+// ExtendDeadline godoc
+// @Summary      Prorrogar prazo de candidatura
+// @Description  Adia o prazo de candidatura da vaga (owner ou recruiter da equipe) sem alterar seu status: uma vaga aberta volta a aceitar candidaturas e uma vaga encerrada continua encerrada. O novo prazo deve ser futuro, posterior ao atual e não posterior a expires_at. Candidatos que salvaram a vaga voltam a ser avisados antes do novo prazo.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body ExtendDeadlineRequest true "Novo prazo"
+// @Success      200 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/extend-deadline [post]
+func (h *JobHandler) ExtendDeadline(c *gin.Context) {
+	var req ExtendDeadlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadJobFor(c, models.CapabilityEditJob, "You can only update jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	if job.Status == models.JobStatusArchived {
+		c.JSON(http.StatusConflict, gin.H{"error": "Archived jobs cannot be changed"})
+		return
+	}
+
+	deadline := req.ApplicationDeadline
+	if !deadline.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "application_deadline must be in the future"})
+		return
+	}
+	if job.ApplicationDeadline != nil && !deadline.After(*job.ApplicationDeadline) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The new deadline must be later than the current one"})
+		return
+	}
+
+	job.ApplicationDeadline = &deadline
+	if err := job.ValidateSchedule(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	if err := h.jobRepo.Update(job); err != nil {
+	if err := h.jobRepo.Update(job, &claims.UserID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
 		return
 	}
+	if err := h.bookmarkRepo.ResetClosingNotices(job.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update bookmarks"})
+		return
+	}
 
 	c.JSON(http.StatusOK, job.ToResponse(false))
 }
 
 // Delete godoc
 // @Summary      Deletar vaga
-// @Description  Remove uma vaga de emprego (apenas o admin que criou)
+// @Description  Move a vaga e suas candidaturas para a lixeira (apenas owners da equipe). Podem ser restauradas com POST /jobs/{id}/restore até serem excluídas definitivamente após o período de retenção.
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
@@ -311,9 +898,6 @@ func (h *JobHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	userClaims, _ := c.Get(middleware.UserContextKey)
-	claims := userClaims.(*jwt.Claims)
-
 	job, err := h.jobRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -324,8 +908,7 @@ func (h *JobHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if job.RecruiterID != claims.UserID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own jobs"})
+	if !h.access.Authorize(c, job, models.CapabilityDeleteJob, "Only job owners can delete a job") {
 		return
 	}
 
@@ -336,3 +919,971 @@ func (h *JobHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Job deleted successfully"})
 }
+
+// Trash godoc
+// @Summary      Listar lixeira de vagas
+// @Description  Lista as vagas excluídas das quais o usuário é criador ou membro da equipe, com a data de exclusão definitiva e quantas candidaturas foram excluídas junto
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.TrashedJobResponse
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/trash [get]
+func (h *JobHandler) Trash(c *gin.Context) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	jobs, err := h.jobRepo.FindDeletedByTeamMember(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get deleted jobs"})
+		return
+	}
+
+	jobIDs := make([]uuid.UUID, len(jobs))
+	for i := range jobs {
+		jobIDs[i] = jobs[i].ID
+	}
+	counts, err := h.jobRepo.TrashedApplicationCounts(jobIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get deleted jobs"})
+		return
+	}
+
+	responses := make([]models.TrashedJobResponse, len(jobs))
+	for i := range jobs {
+		responses[i] = jobs[i].ToTrashedResponse(h.cfg.Jobs.TrashRetention, counts[jobs[i].ID])
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// Restore godoc
+// @Summary      Restaurar vaga da lixeira
+// @Description  Restaura uma vaga excluída junto com as candidaturas excluídas com ela (apenas owners da equipe). Candidaturas retiradas antes da exclusão continuam excluídas.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/restore [post]
+func (h *JobHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.FindDeletedByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Deleted job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	if !h.access.Authorize(c, job, models.CapabilityDeleteJob, "Only job owners can restore a job") {
+		return
+	}
+
+	if err := h.jobRepo.Restore(job); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore job"})
+		return
+	}
+
+	job.ApplySchedule(time.Now())
+	c.JSON(http.StatusOK, job.ToResponse(false))
+}
+
+// Submit godoc
+// @Summary      Enviar vaga para aprovação
+// @Description  Envia um rascunho de vaga para aprovação (owner ou recruiter da equipe). Vagas que violam a política de conformidade (ex.: faixa salarial obrigatória, requisitos de idade) são recusadas com 422 e a lista de violações.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      422 {object} ComplianceErrorResponse
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/submit [post]
+func (h *JobHandler) Submit(c *gin.Context) {
+	job, ok := h.loadJob(c)
+	if !ok {
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	if !h.access.Authorize(c, job, models.CapabilityEditJob, "You can only submit jobs you recruit for") {
+		return
+	}
+
+	if !job.Status.CanTransitionTo(models.JobStatusPendingApproval) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft jobs can be submitted for approval"})
+		return
+	}
+
+	if !h.checkCompliance(c, job) {
+		return
+	}
+
+	job.Status = models.JobStatusPendingApproval
+	if err := h.jobRepo.Update(job, &claims.UserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job.ToResponse(false))
+}
+
+// Approve godoc
+// @Summary      Aprovar vaga
+// @Description  Aprova uma vaga pendente, publicando-a (ou agendando-a se publish_at estiver no futuro). Vagas que violam a política de conformidade não são aprovadas (422).
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body ReviewJobRequest false "Comentário do revisor"
+// @Success      200 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      422 {object} ComplianceErrorResponse
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/approve [post]
+func (h *JobHandler) Approve(c *gin.Context) {
+	var req ReviewJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	job, ok := h.loadReviewableJob(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	if job.ExpiresAt == nil && h.cfg.Jobs.DefaultExpiration > 0 {
+		start := now
+		if job.PublishAt != nil && job.PublishAt.After(now) {
+			start = *job.PublishAt
+		}
+		expiresAt := start.Add(h.cfg.Jobs.DefaultExpiration)
+		job.ExpiresAt = &expiresAt
+	}
+
+	job.Status = models.JobStatusOpen
+	job.ApplySchedule(now)
+
+	if !h.checkCompliance(c, job) {
+		return
+	}
+
+	h.recordReview(c, job, models.JobReviewApproved, req.Comment)
+}
+
+// Reject godoc
+// @Summary      Rejeitar vaga
+// @Description  Devolve uma vaga pendente para rascunho com o comentário do revisor
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body RejectJobRequest true "Comentário do revisor"
+// @Success      200 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/reject [post]
+func (h *JobHandler) Reject(c *gin.Context) {
+	var req RejectJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadReviewableJob(c)
+	if !ok {
+		return
+	}
+
+	job.Status = models.JobStatusDraft
+
+	h.recordReview(c, job, models.JobReviewRejected, req.Comment)
+}
+
+// GetReviews godoc
+// @Summary      Histórico de aprovação da vaga
+// @Description  Retorna as aprovações e rejeições da vaga com os comentários dos revisores (membros da equipe de contratação)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {array} models.JobReviewResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/reviews [get]
+func (h *JobHandler) GetReviews(c *gin.Context) {
+	job, ok := h.loadJobFor(c, models.CapabilityViewJob, "You can only view reviews of jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	reviews, err := h.reviewRepo.FindByJobID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reviews"})
+		return
+	}
+
+	responses := make([]models.JobReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = review.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// SetQuestions godoc
+// @Summary      Definir perguntas de triagem
+// @Description  Substitui as perguntas de triagem da vaga, na ordem enviada (owner ou recruiter da equipe). Respostas já enviadas são mantidas; as regras eliminatórias da vaga são removidas.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body SetJobQuestionsRequest true "Perguntas"
+// @Success      200 {array} models.JobQuestionResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/questions [put]
+func (h *JobHandler) SetQuestions(c *gin.Context) {
+	var req SetJobQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadJobFor(c, models.CapabilityEditJob, "You can only edit questions of jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	questions := make([]models.JobQuestion, len(req.Questions))
+	for i, q := range req.Questions {
+		questions[i] = models.JobQuestion{
+			Prompt:   strings.TrimSpace(q.Prompt),
+			Type:     q.Type,
+			Options:  models.StringList(q.Options),
+			Required: q.Required,
+		}
+		if err := questions[i].Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("questions[%d]: %s", i, err.Error())})
+			return
+		}
+	}
+
+	if err := h.questionRepo.ReplaceForJob(job.ID, questions); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save questions"})
+		return
+	}
+
+	responses := make([]models.JobQuestionResponse, len(questions))
+	for i, question := range questions {
+		responses[i] = question.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// SetSkills godoc
+// @Summary      Definir habilidades da vaga
+// @Description  Substitui as habilidades da vaga, informadas por nome ou apelido da taxonomia, com nível required ou nice_to_have (owner ou recruiter da equipe)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body SetJobSkillsRequest true "Habilidades"
+// @Success      200 {array} models.JobSkillResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/skills [put]
+func (h *JobHandler) SetSkills(c *gin.Context) {
+	var req SetJobSkillsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadJobFor(c, models.CapabilityEditJob, "You can only edit skills of jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	jobSkills := make([]models.JobSkill, 0, len(req.Skills))
+	seen := make(map[uuid.UUID]bool)
+	var unknown []string
+	for _, r := range req.Skills {
+		skills, notFound, err := h.skillRepo.Resolve([]string{r.Name})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve skills"})
+			return
+		}
+		if len(notFound) > 0 || len(skills) == 0 {
+			unknown = append(unknown, r.Name)
+			continue
+		}
+		if seen[skills[0].ID] {
+			continue
+		}
+		seen[skills[0].ID] = true
+
+		level := r.Level
+		if level == "" {
+			level = models.SkillLevelRequired
+		}
+		jobSkills = append(jobSkills, models.JobSkill{SkillID: skills[0].ID, Level: level, Skill: skills[0]})
+	}
+
+	if len(unknown) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown skills", "skills": unknown})
+		return
+	}
+
+	if err := h.jobRepo.SetSkills(job.ID, jobSkills); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save skills"})
+		return
+	}
+
+	responses := make([]models.JobSkillResponse, len(jobSkills))
+	for i, skill := range jobSkills {
+		responses[i] = skill.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetKnockoutRules godoc
+// @Summary      Listar regras eliminatórias
+// @Description  Lista as regras eliminatórias da vaga (equipe de contratação da vaga)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {array} models.KnockoutRuleResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/knockout-rules [get]
+func (h *JobHandler) GetKnockoutRules(c *gin.Context) {
+	job, ok := h.loadJobFor(c, models.CapabilityViewJob, "You can only view knockout rules of jobs you are on the team of")
+	if !ok {
+		return
+	}
+
+	rules, err := h.ruleRepo.FindByJobID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get knockout rules"})
+		return
+	}
+
+	responses := make([]models.KnockoutRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = rule.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// SetKnockoutRules godoc
+// @Summary      Definir regras eliminatórias
+// @Description  Substitui as regras eliminatórias da vaga (owner ou recruiter da equipe). Cada regra aponta para uma pergunta de triagem; quando a resposta não atende ao critério a candidatura é rejeitada ou sinalizada.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body SetKnockoutRulesRequest true "Regras"
+// @Success      200 {array} models.KnockoutRuleResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/knockout-rules [put]
+func (h *JobHandler) SetKnockoutRules(c *gin.Context) {
+	var req SetKnockoutRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadJobFor(c, models.CapabilityEditJob, "You can only edit knockout rules of jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	questions := make(map[uuid.UUID]*models.JobQuestion, len(job.Questions))
+	for i := range job.Questions {
+		questions[job.Questions[i].ID] = &job.Questions[i]
+	}
+
+	rules := make([]models.KnockoutRule, len(req.Rules))
+	for i, r := range req.Rules {
+		question, ok := questions[r.QuestionID]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("rules[%d]: question not found on this job", i)})
+			return
+		}
+		rules[i] = models.KnockoutRule{
+			QuestionID:       r.QuestionID,
+			Operator:         r.Operator,
+			Value:            models.JSONValue(r.Value),
+			Action:           r.Action,
+			Message:          strings.TrimSpace(r.Message),
+			NoticeDelayHours: r.NoticeDelayHours,
+		}
+		if err := rules[i].Validate(question); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("rules[%d]: %s", i, err.Error())})
+			return
+		}
+	}
+
+	if err := h.ruleRepo.ReplaceForJob(job.ID, rules); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save knockout rules"})
+		return
+	}
+
+	responses := make([]models.KnockoutRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = rule.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetRevisions godoc
+// @Summary      Histórico de revisões da vaga
+// @Description  Lista as revisões imutáveis da vaga, da mais recente para a mais antiga (equipe de contratação da vaga). Revisões com `system: true` foram registradas pelo servidor e não têm `editor_id`: publicação ou encerramento automático pelo agendador, ou o primeiro registro de uma vaga anterior ao histórico.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {array} models.JobRevisionResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/revisions [get]
+func (h *JobHandler) GetRevisions(c *gin.Context) {
+	job, ok := h.loadJobFor(c, models.CapabilityViewJob, "You can only view revisions of jobs you are on the team of")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.jobRepo.FindRevisions(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get revisions"})
+		return
+	}
+
+	responses := make([]models.JobRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = revision.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// DiffRevisions godoc
+// @Summary      Comparar revisões da vaga
+// @Description  Retorna as diferenças campo a campo entre duas revisões da vaga
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        from query integer true "Revisão de origem"
+// @Param        to query integer true "Revisão de destino"
+// @Success      200 {object} models.JobRevisionDiff
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/revisions/diff [get]
+func (h *JobHandler) DiffRevisions(c *gin.Context) {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be revision numbers"})
+		return
+	}
+
+	job, ok := h.loadJobFor(c, models.CapabilityViewJob, "You can only view revisions of jobs you are on the team of")
+	if !ok {
+		return
+	}
+
+	fromRevision, err := h.jobRepo.FindRevision(job.ID, from)
+	if err != nil {
+		h.revisionError(c, err)
+		return
+	}
+
+	toRevision, err := h.jobRepo.FindRevision(job.ID, to)
+	if err != nil {
+		h.revisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.JobRevisionDiff{
+		JobID:   job.ID,
+		From:    from,
+		To:      to,
+		Changes: models.DiffSnapshots(fromRevision.Snapshot, toRevision.Snapshot),
+	})
+}
+
+func (h *JobHandler) revisionError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get revision"})
+}
+
+// Clone godoc
+// @Summary      Duplicar vaga
+// @Description  Copia uma vaga para um novo rascunho, com sobrescrita opcional de campos (owner ou recruiter da equipe)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body JobOverridesRequest false "Campos a sobrescrever"
+// @Success      201 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/clone [post]
+func (h *JobHandler) Clone(c *gin.Context) {
+	var req JobOverridesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	source, ok := h.loadJobFor(c, models.CapabilityEditJob, "You can only clone jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	h.createDraft(c, source.CloneAsDraft(source.RecruiterID), req)
+}
+
+// CreateFromTemplate godoc
+// @Summary      Criar vaga a partir de modelo
+// @Description  Cria um rascunho de vaga a partir de um modelo, com sobrescrita opcional de campos
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        templateId path string true "Template ID"
+// @Param        request body JobOverridesRequest false "Campos a sobrescrever"
+// @Success      201 {object} models.JobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/from-template/{templateId} [post]
+func (h *JobHandler) CreateFromTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var req JobOverridesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	template, err := h.templateRepo.FindByID(templateID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get template"})
+		return
+	}
+	if err != nil || !template.VisibleTo(claims.UserID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+
+	h.createDraft(c, template.NewJob(claims.UserID), req)
+}
+
+// createDraft applies overrides to a new draft job, validates it and stores
+// it on behalf of the caller.
+func (h *JobHandler) createDraft(c *gin.Context, job *models.Job, overrides JobOverridesRequest) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	job.RecruiterID = claims.UserID
+	applyJobContent(job, overrides.JobContentRequest)
+	job.PublishAt = overrides.PublishAt
+	job.ExpiresAt = overrides.ExpiresAt
+	job.ApplicationDeadline = overrides.ApplicationDeadline
+
+	if err := job.ValidateRequired(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if job.Type != models.JobTypeRemote && job.Type != models.JobTypeOnsite && job.Type != models.JobTypeHybrid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of remote, onsite, hybrid"})
+		return
+	}
+	if err := validateNewJob(job, time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.jobRepo.Create(job, &claims.UserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, job.ToResponse(false))
+}
+
+// loadJob parses the :id path parameter and loads the job, writing the error
+// response itself when it returns false.
+func (h *JobHandler) loadJob(c *gin.Context) (*models.Job, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return nil, false
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return nil, false
+	}
+
+	return job, true
+}
+
+// loadJobFor loads the job and checks that the caller has the capability on
+// it through the hiring team.
+func (h *JobHandler) loadJobFor(c *gin.Context, capability models.JobCapability, forbiddenMessage string) (*models.Job, bool) {
+	job, ok := h.loadJob(c)
+	if !ok {
+		return nil, false
+	}
+
+	if !h.access.Authorize(c, job, capability, forbiddenMessage) {
+		return nil, false
+	}
+
+	return job, true
+}
+
+// loadReviewableJob loads a job awaiting approval and checks that the caller
+// may review it.
+func (h *JobHandler) loadReviewableJob(c *gin.Context) (*models.Job, bool) {
+	job, ok := h.loadJob(c)
+	if !ok {
+		return nil, false
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	if job.Status != models.JobStatusPendingApproval {
+		c.JSON(http.StatusConflict, gin.H{"error": "Job is not pending approval"})
+		return nil, false
+	}
+
+	if !h.cfg.Jobs.AllowSelfApproval {
+		canEdit, err := h.access.Can(job, claims.UserID, models.CapabilityEditJob)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check job permissions"})
+			return nil, false
+		}
+		if canEdit {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You cannot review your own job"})
+			return nil, false
+		}
+	}
+
+	return job, true
+}
+
+func (h *JobHandler) recordReview(c *gin.Context, job *models.Job, decision models.JobReviewDecision, comment string) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	review := &models.JobReview{
+		JobID:      job.ID,
+		ReviewerID: claims.UserID,
+		Decision:   decision,
+		Comment:    comment,
+	}
+	if err := h.jobRepo.RecordReview(job, review); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job.ToResponse(false))
+}
+
+// translate serves each response in the locale negotiated from the lang
+// query parameter or Accept-Language, falling back to the default locale and
+// then to the job's own content.
+func (h *JobHandler) translate(c *gin.Context, responses []models.JobResponse) error {
+	return translateJobs(c, h.translations, responses)
+}
+
+// translateJobs is translate for handlers other than JobHandler.
+func translateJobs(c *gin.Context, translationRepo *repository.JobTranslationRepository, responses []models.JobResponse) error {
+	c.Header("Vary", "Accept-Language")
+	locale := models.NegotiateLocale(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+	jobIDs := make([]uuid.UUID, 0, len(responses))
+	for i := range responses {
+		if responses[i].Locale != locale {
+			jobIDs = append(jobIDs, responses[i].ID)
+		}
+	}
+
+	translations, err := translationRepo.FindForJobs(jobIDs, []models.Locale{locale, models.DefaultLocale})
+	if err != nil {
+		return err
+	}
+
+	for i := range responses {
+		responses[i].Translate(locale, translations[responses[i].ID])
+	}
+	return nil
+}
+
+// markBookmarks sets Bookmarked on each response when the caller is an
+// authenticated candidate.
+func (h *JobHandler) markBookmarks(c *gin.Context, responses []models.JobResponse) error {
+	candidateID, ok := currentCandidateID(c)
+	if !ok {
+		return nil
+	}
+
+	jobIDs := make([]uuid.UUID, len(responses))
+	for i := range responses {
+		jobIDs[i] = responses[i].ID
+	}
+
+	bookmarked, err := h.bookmarkRepo.BookmarkedJobIDs(candidateID, jobIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range responses {
+		flag := bookmarked[responses[i].ID]
+		responses[i].Bookmarked = &flag
+	}
+	return nil
+}
+
+// trackView queues a view of the job by the caller. Signed-in candidates
+// are identified by their ID; anonymous visitors by a signed cookie holding
+// a hash of their address and user agent, set on their first view.
+func (h *JobHandler) trackView(c *gin.Context, jobID uuid.UUID, now time.Time) {
+	var viewerKey string
+	var userID *uuid.UUID
+	if candidateID, ok := currentCandidateID(c); ok {
+		viewerKey = analytics.ViewerKey("user:" + candidateID.String())
+		userID = &candidateID
+	} else if key, ok := h.viewerFromCookie(c); ok {
+		viewerKey = key
+	} else {
+		viewerKey = analytics.ViewerKey("anon:" + c.ClientIP() + "|" + c.Request.UserAgent())
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(viewerCookie, analytics.ViewerCookie(viewerKey, h.cfg.JWT.Secret), viewerCookieMaxAge, "/", "", false, true)
+	}
+
+	referrer := analytics.Referrer(c.Query("ref"), c.Request.Referer())
+	h.views.Track(jobID, viewerKey, userID, referrer, now)
+}
+
+func (h *JobHandler) viewerFromCookie(c *gin.Context) (string, bool) {
+	cookie, err := c.Cookie(viewerCookie)
+	if err != nil {
+		return "", false
+	}
+	return analytics.ViewerFromCookie(cookie, h.cfg.JWT.Secret)
+}
+
+// rememberSource stores where the visit came from in the job's signed
+// source cookie. Direct visits keep the source already stored, so coming
+// back to the job later does not erase the board or employee that brought
+// the candidate, and visits to other jobs never touch it. A referral is
+// only kept when ?referred_by= names an employee other than the visitor;
+// when that cannot be checked it is dropped rather than failing the page.
+func (h *JobHandler) rememberSource(c *gin.Context, jobID uuid.UUID) {
+	source := analytics.Source(c.Request.URL.Query(), c.Request.Referer())
+	if source.ReferredByID != nil {
+		candidateID, _ := currentCandidateID(c)
+		valid := *source.ReferredByID != candidateID
+		if valid {
+			valid, _ = isEmployee(h.userRepo, *source.ReferredByID)
+		}
+		if !valid {
+			source.ReferredByID = nil
+			source.ResolveChannel()
+		}
+	}
+	if source.IsDirect() {
+		return
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sourceCookieName(jobID), analytics.EncodeSource(jobID, source, h.cfg.JWT.Secret), sourceCookieMaxAge, "/", "", false, true)
+}
+
+func sourceCookieName(jobID uuid.UUID) string {
+	return sourceCookiePrefix + jobID.String()
+}
+
+// currentCandidateID returns the caller's ID when the request carries a
+// candidate's token.
+func currentCandidateID(c *gin.Context) (uuid.UUID, bool) {
+	userClaims, exists := c.Get(middleware.UserContextKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	claims, ok := userClaims.(*jwt.Claims)
+	if !ok || claims.Role != models.RoleCandidate {
+		return uuid.Nil, false
+	}
+	return claims.UserID, true
+}
+
+// sameTime reports whether a and b are both nil or the same instant.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func isAdmin(c *gin.Context) bool {
+	userClaims, exists := c.Get(middleware.UserContextKey)
+	if !exists {
+		return false
+	}
+	claims, ok := userClaims.(*jwt.Claims)
+	return ok && claims.Role == models.RoleAdmin
+}
+
+// applyJobContent copies the fields set in req onto job.
+func applyJobContent(job *models.Job, req JobContentRequest) {
+	if req.Title != "" {
+		job.Title = req.Title
+	}
+	if req.Description != "" {
+		job.Description = req.Description
+	}
+	if req.Salary != nil && req.SalaryMin == nil && req.SalaryMax == nil {
+		job.SalaryMin, job.SalaryMax = req.Salary, req.Salary
+	}
+	if req.SalaryMin != nil {
+		job.SalaryMin = req.SalaryMin
+	}
+	if req.SalaryMax != nil {
+		job.SalaryMax = req.SalaryMax
+	}
+	if req.SalaryCurrency != "" {
+		job.SalaryCurrency = strings.ToUpper(req.SalaryCurrency)
+	}
+	if req.SalaryPeriod != "" {
+		job.SalaryPeriod = req.SalaryPeriod
+	}
+	if req.SalaryNegotiable != nil {
+		job.SalaryNegotiable = *req.SalaryNegotiable
+	}
+	if req.Location != "" {
+		job.Location = req.Location
+	}
+	if req.Type != "" {
+		job.Type = req.Type
+	}
+	if req.ContractType != "" {
+		job.ContractType = req.ContractType
+	}
+	if req.Locale != "" {
+		job.Locale = req.Locale
+	}
+	if req.Openings > 0 {
+		job.Openings = req.Openings
+	}
+	if req.AutoRejectOnFill != nil {
+		job.AutoRejectOnFill = *req.AutoRejectOnFill
+	}
+	if req.FillMessage != "" {
+		job.FillMessage = strings.TrimSpace(req.FillMessage)
+	}
+}
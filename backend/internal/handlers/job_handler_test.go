@@ -0,0 +1,508 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/analytics"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// around matches a time argument within a second of want, for times the
+// handler takes from the clock.
+type around time.Time
+
+func (a around) Match(v driver.Value) bool {
+	got, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := got.Sub(time.Time(a))
+	return diff > -time.Second && diff < time.Second
+}
+
+func TestJobHandler_List_ClosingSoon(t *testing.T) {
+	t.Run("should keep jobs closing within the closing soon window", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		c, w := newRequest(t, http.MethodGet, "/api/jobs?closing_soon=true", nil, uuid.Nil, "")
+
+		now := time.Now()
+		window := regexp.QuoteMeta(`(LEAST(jobs.application_deadline, jobs.expires_at) > $7 AND LEAST(jobs.application_deadline, jobs.expires_at) <= $8)`)
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "jobs" .*`+window).
+			WithArgs("open", "open", "closed", "archived", around(now), around(now), around(now), around(now.Add(72*time.Hour))).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT \* FROM "jobs" .*` + window).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+
+		h.List(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_List_ClosingSoonValidation(t *testing.T) {
+	t.Run("should reject a closing_soon that is not a boolean", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		c, w := newRequest(t, http.MethodGet, "/api/jobs?closing_soon=soon", nil, uuid.Nil, "")
+
+		h.List(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_ExtendDeadline(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	recruiterID := uuid.New()
+
+	openJob := func() *models.Job {
+		published := now.AddDate(0, 0, -10)
+		expires := now.AddDate(0, 0, 30)
+		deadline := now.AddDate(0, 0, 2)
+		return &models.Job{
+			ID:                  uuid.New(),
+			Title:               "Desenvolvedor Go",
+			Description:         "APIs em Go.",
+			Location:            "Niterói, RJ",
+			Type:                models.JobTypeOnsite,
+			Status:              models.JobStatusOpen,
+			RecruiterID:         recruiterID,
+			Openings:            1,
+			PublishedAt:         &published,
+			ExpiresAt:           &expires,
+			ApplicationDeadline: &deadline,
+			Slug:                "desenvolvedor-go",
+		}
+	}
+	extend := func(t *testing.T, h *JobHandler, job *models.Job, userID uuid.UUID, deadline time.Time) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodPost, "/api/jobs/"+job.ID.String()+"/extend-deadline",
+			gin.H{"application_deadline": deadline}, userID, models.RoleAdmin,
+			gin.Param{Key: "id", Value: job.ID.String()})
+		h.ExtendDeadline(c)
+		return w
+	}
+
+	t.Run("should move the deadline and re-arm closing notices", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+		newDeadline := now.AddDate(0, 0, 10)
+
+		expectFindJob(mock, job)
+		expectUpdateJob(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "bookmarks" SET "closing_notice_sent_at"=$1 WHERE job_id = $2 AND closing_notice_sent_at IS NOT NULL`)).
+			WithArgs(nil, job.ID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		w := extend(t, h, job, recruiterID, newDeadline)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.JobResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		require.NotNil(t, resp.ApplicationDeadline)
+		assert.True(t, newDeadline.Equal(*resp.ApplicationDeadline))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should record the new deadline as a revision", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+		job.SalaryCurrency = "BRL"
+		job.SalaryPeriod = models.SalaryPeriodMonth
+		snapshot, err := json.Marshal(job.Snapshot())
+		require.NoError(t, err)
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE id = $1 LIMIT 1 FOR UPDATE`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(job.ID))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_revisions"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "revision", "changed_fields", "snapshot"}).
+				AddRow(uuid.New(), job.ID, 1, `[]`, string(snapshot)))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_revisions" ("job_id","revision","editor_id","system","changed_fields","snapshot","created_at")`)).
+			WithArgs(job.ID, 2, recruiterID, false, `["application_deadline"]`, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "bookmarks"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		w := extend(t, h, job, recruiterID, now.AddDate(0, 0, 10))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse to bring the deadline forward", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+
+		expectFindJob(mock, job)
+
+		w := extend(t, h, job, recruiterID, now.AddDate(0, 0, 1))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "later than the current one")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse a deadline in the past", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+		job.ApplicationDeadline = nil
+
+		expectFindJob(mock, job)
+
+		w := extend(t, h, job, recruiterID, now.Add(-time.Hour))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "must be in the future")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse a deadline after the expiry", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+
+		expectFindJob(mock, job)
+
+		w := extend(t, h, job, recruiterID, job.ExpiresAt.AddDate(0, 0, 1))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse archived jobs", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+		job.Status = models.JobStatusArchived
+
+		expectFindJob(mock, job)
+
+		w := extend(t, h, job, recruiterID, now.AddDate(0, 0, 10))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid admins outside the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := openJob()
+		outsider := uuid.New()
+
+		expectFindJob(mock, job)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_team_members"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "user_id", "role"}))
+
+		w := extend(t, h, job, outsider, now.AddDate(0, 0, 10))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_Update_Status(t *testing.T) {
+	recruiterID := uuid.New()
+
+	draftJob := func() *models.Job {
+		return &models.Job{
+			ID:          uuid.New(),
+			Title:       "Desenvolvedor Go",
+			Description: "APIs em Go.",
+			Location:    "Niterói, RJ",
+			Type:        models.JobTypeOnsite,
+			Status:      models.JobStatusDraft,
+			RecruiterID: recruiterID,
+			Openings:    1,
+			Slug:        "desenvolvedor-go",
+		}
+	}
+	update := func(t *testing.T, h *JobHandler, job *models.Job, status models.JobStatus) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodPut, "/api/jobs/"+job.ID.String(),
+			gin.H{"status": status}, recruiterID, models.RoleAdmin,
+			gin.Param{Key: "id", Value: job.ID.String()})
+		h.Update(c)
+		return w
+	}
+
+	t.Run("should archive a draft", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := draftJob()
+
+		expectFindJob(mock, job)
+		expectUpdateJob(mock)
+
+		w := update(t, h, job, models.JobStatusArchived)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.JobResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.JobStatusArchived, resp.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse to publish a draft", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := draftJob()
+
+		expectFindJob(mock, job)
+
+		w := update(t, h, job, models.JobStatusOpen)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "submitted and approved")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse to publish a pending job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := draftJob()
+		job.Status = models.JobStatusPendingApproval
+
+		expectFindJob(mock, job)
+
+		w := update(t, h, job, models.JobStatusOpen)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "submitted and approved")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse transitions outside the state machine", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := draftJob()
+		job.Status = models.JobStatusArchived
+
+		expectFindJob(mock, job)
+
+		w := update(t, h, job, models.JobStatusOpen)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Cannot change job status from archived to open")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_Reject(t *testing.T) {
+	recruiterID := uuid.New()
+
+	pendingJob := func() *models.Job {
+		return &models.Job{
+			ID:          uuid.New(),
+			Title:       "Desenvolvedor Go",
+			Description: "APIs em Go.",
+			Location:    "Niterói, RJ",
+			Type:        models.JobTypeOnsite,
+			Status:      models.JobStatusPendingApproval,
+			RecruiterID: recruiterID,
+			Openings:    1,
+			Slug:        "desenvolvedor-go",
+		}
+	}
+	reject := func(t *testing.T, h *JobHandler, job *models.Job) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodPost, "/api/jobs/"+job.ID.String()+"/reject",
+			gin.H{"comment": "Faltou a faixa salarial"}, recruiterID, models.RoleAdmin,
+			gin.Param{Key: "id", Value: job.ID.String()})
+		h.Reject(c)
+		return w
+	}
+
+	t.Run("should save the status and the review in one transaction", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := pendingJob()
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRecordRevision(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_reviews"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		w := reject(t, h, job)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.JobResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.JobStatusDraft, resp.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll back the status when the review fails", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		job := pendingJob()
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRecordRevision(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_reviews"`)).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		w := reject(t, h, job)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_GetReviews(t *testing.T) {
+	recruiterID := uuid.New()
+	job := &models.Job{
+		ID:          uuid.New(),
+		Title:       "Desenvolvedor Go",
+		Description: "APIs em Go.",
+		Location:    "Niterói, RJ",
+		Type:        models.JobTypeOnsite,
+		Status:      models.JobStatusOpen,
+		RecruiterID: recruiterID,
+		Openings:    1,
+		Slug:        "desenvolvedor-go",
+	}
+	getReviews := func(t *testing.T, h *JobHandler, userID uuid.UUID, role models.UserRole) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodGet, "/api/jobs/"+job.ID.String()+"/reviews",
+			nil, userID, role, gin.Param{Key: "id", Value: job.ID.String()})
+		h.GetReviews(c)
+		return w
+	}
+
+	t.Run("should list reviews for the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindJob(mock, job)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_reviews" WHERE job_id = $1`)).
+			WithArgs(job.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "reviewer_id", "decision", "comment"}).
+				AddRow(uuid.New(), job.ID, recruiterID, models.JobReviewApproved, "Ok"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(recruiterID))
+
+		w := getReviews(t, h, recruiterID, models.RoleAdmin)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp []models.JobReviewResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Len(t, resp, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid admins outside the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindJob(mock, job)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_team_members"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "user_id", "role"}))
+
+		w := getReviews(t, h, uuid.New(), models.RoleAdmin)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_RememberSource(t *testing.T) {
+	jobID := uuid.New()
+	employeeID := uuid.New()
+
+	visit := func(t *testing.T, h *JobHandler, query string) (models.ApplicationSource, bool) {
+		c, w := newRequest(t, http.MethodGet, "/api/jobs/"+jobID.String()+"?"+query, nil, uuid.Nil, "")
+		h.rememberSource(c, jobID)
+
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == sourceCookieName(jobID) {
+				return analytics.DecodeSource(cookie.Value, jobID, h.cfg.JWT.Secret), true
+			}
+		}
+		return models.ApplicationSource{}, false
+	}
+	expectFindUser := func(mock sqlmock.Sqlmock, id uuid.UUID, role models.UserRole) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow(id, role))
+	}
+
+	t.Run("should remember referrals by employees", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindUser(mock, employeeID, models.RoleAdmin)
+
+		source, ok := visit(t, h, "referred_by="+employeeID.String())
+
+		require.True(t, ok)
+		assert.Equal(t, models.SourceChannelReferral, source.Channel)
+		assert.Equal(t, &employeeID, source.ReferredByID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should drop referrals by IDs that are not employees", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		unknownID, candidateID := uuid.New(), uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+			WithArgs(unknownID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectFindUser(mock, candidateID, models.RoleCandidate)
+
+		_, ok := visit(t, h, "referred_by="+unknownID.String())
+		assert.False(t, ok)
+
+		source, ok := visit(t, h, "utm_source=indeed&referred_by="+candidateID.String())
+		require.True(t, ok)
+		assert.Nil(t, source.ReferredByID)
+		assert.Equal(t, "indeed", source.Channel)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should drop the referral when it cannot be checked", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+			WillReturnError(errors.New("connection reset"))
+
+		_, ok := visit(t, h, "referred_by="+employeeID.String())
+
+		assert.False(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
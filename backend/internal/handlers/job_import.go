@@ -0,0 +1,422 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+)
+
+const (
+	maxImportRows  = 1000
+	maxImportBytes = 5 << 20
+
+	importModeAtomic = "atomic"
+	importModePerRow = "per_row"
+)
+
+type ImportRowResult struct {
+	// Row is the 1-based position of the job in the file, not counting the
+	// CSV header.
+	Row    int                 `json:"row"`
+	Title  string              `json:"title,omitempty"`
+	Errors []string            `json:"errors,omitempty"`
+	Job    *models.JobResponse `json:"job,omitempty"`
+}
+
+type ImportJobsResponse struct {
+	DryRun  bool              `json:"dry_run"`
+	Mode    string            `json:"mode"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Invalid int               `json:"invalid"`
+	Created int               `json:"created"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// importRow is a decoded row with the job built from it, or the reasons it
+// was rejected.
+type importRow struct {
+	request CreateJobRequest
+	job     *models.Job
+	errors  []string
+}
+
+// Import godoc
+// @Summary      Importar vagas em lote
+// @Description  Cria rascunhos de vagas a partir de um CSV (cabeçalho com os campos de POST /jobs) ou JSON (lista de objetos de POST /jobs), enviado no corpo ou como arquivo multipart no campo "file". Cada linha é validada com as mesmas regras de POST /jobs. Com mode=atomic (padrão) nada é criado se alguma linha for inválida; com mode=per_row as linhas válidas são criadas e as inválidas reportadas. dry_run=true apenas valida.
+// @Tags         jobs
+// @Accept       json
+// @Accept       text/csv
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        dry_run query boolean false "Apenas validar, sem criar vagas" default(false)
+// @Param        mode query string false "Como criar as linhas válidas (atomic, per_row)" default(atomic)
+// @Param        format query string false "Formato do arquivo quando não indicado pelo Content-Type (csv, json)"
+// @Success      200 {object} ImportJobsResponse
+// @Success      201 {object} ImportJobsResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      422 {object} ImportJobsResponse
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/import [post]
+func (h *JobHandler) Import(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	mode := c.DefaultQuery("mode", importModeAtomic)
+	if mode != importModeAtomic && mode != importModePerRow {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be one of atomic, per_row"})
+		return
+	}
+
+	format, data, err := readImportPayload(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requests []CreateJobRequest
+	var decodeErrors [][]string
+	switch format {
+	case "csv":
+		requests, decodeErrors, err = decodeImportCSV(data)
+	default:
+		requests, decodeErrors, err = decodeImportJSON(data)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	rows := buildImportRows(requests, decodeErrors, claims, time.Now())
+
+	resp := ImportJobsResponse{
+		DryRun: dryRun,
+		Mode:   mode,
+		Total:  len(rows),
+		Rows:   make([]ImportRowResult, len(rows)),
+	}
+	var valid []*models.Job
+	for i, row := range rows {
+		resp.Rows[i] = ImportRowResult{Row: i + 1, Title: row.request.Title, Errors: row.errors}
+		if row.job != nil {
+			valid = append(valid, row.job)
+		}
+	}
+	resp.Valid = len(valid)
+	resp.Invalid = resp.Total - resp.Valid
+
+	if dryRun {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	if mode == importModeAtomic {
+		if resp.Invalid > 0 {
+			c.JSON(http.StatusUnprocessableEntity, resp)
+			return
+		}
+		if err := h.jobRepo.CreateMany(valid, &claims.UserID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import jobs"})
+			return
+		}
+	} else {
+		for _, row := range rows {
+			if row.job == nil {
+				continue
+			}
+			if err := h.jobRepo.Create(row.job, &claims.UserID); err != nil {
+				row.errors = append(row.errors, importCreateError(err))
+				row.job = nil
+			}
+		}
+	}
+
+	for i, row := range rows {
+		resp.Rows[i].Errors = row.errors
+		if row.job != nil {
+			jobResp := row.job.ToResponse(false)
+			resp.Rows[i].Job = &jobResp
+			resp.Created++
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Created == resp.Total {
+		status = http.StatusCreated
+	}
+	c.JSON(status, resp)
+}
+
+// importCreateError describes why a valid row could not be saved.
+func importCreateError(err error) string {
+	if errors.Is(err, repository.ErrSlugTaken) {
+		return "slug is already in use"
+	}
+	if reason, ok := repository.RejectionReason(err); ok {
+		return reason
+	}
+	return "failed to create job: " + err.Error()
+}
+
+// readImportPayload returns the uploaded file, or the request body, with
+// its format. The format comes from ?format=, then the file extension or
+// Content-Type, and defaults to JSON.
+func readImportPayload(c *gin.Context) (string, []byte, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+	var data []byte
+	var hint string
+	contentType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	if contentType == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return "", nil, errors.New("file is required")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", nil, err
+		}
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			return "", nil, err
+		}
+		hint = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	} else {
+		var err error
+		if data, err = io.ReadAll(c.Request.Body); err != nil {
+			return "", nil, fmt.Errorf("failed to read body: %w", err)
+		}
+		switch contentType {
+		case "text/csv", "application/csv":
+			hint = "csv"
+		case "application/json":
+			hint = "json"
+		}
+	}
+
+	format := c.DefaultQuery("format", hint)
+	switch format {
+	case "csv", "json":
+	case "":
+		format = "json"
+	default:
+		return "", nil, errors.New("format must be one of csv, json")
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", nil, errors.New("import file is empty")
+	}
+	return format, data, nil
+}
+
+// decodeImportJSON accepts a list of job objects, or {"jobs": [...]}.
+// Rows that do not decode get their error instead of a request.
+func decodeImportJSON(data []byte) ([]CreateJobRequest, [][]string, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var wrapped struct {
+			Jobs []json.RawMessage `json:"jobs"`
+		}
+		if err := json.Unmarshal(data, &wrapped); err != nil || wrapped.Jobs == nil {
+			return nil, nil, errors.New("JSON import must be a list of jobs or an object with a jobs list")
+		}
+		raw = wrapped.Jobs
+	}
+	if len(raw) == 0 {
+		return nil, nil, errors.New("import has no jobs")
+	}
+	if len(raw) > maxImportRows {
+		return nil, nil, fmt.Errorf("import is limited to %d jobs", maxImportRows)
+	}
+
+	requests := make([]CreateJobRequest, len(raw))
+	decodeErrors := make([][]string, len(raw))
+	for i, item := range raw {
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&requests[i]); err != nil {
+			decodeErrors[i] = []string{err.Error()}
+		}
+	}
+	return requests, decodeErrors, nil
+}
+
+// decodeImportCSV reads a CSV whose header names CreateJobRequest's JSON
+// fields. Empty cells are left unset; cells that do not parse as their
+// field's type are reported on their row.
+func decodeImportCSV(data []byte) ([]CreateJobRequest, [][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, nil, errors.New("CSV import needs a header and at least one job")
+	}
+	if len(records)-1 > maxImportRows {
+		return nil, nil, fmt.Errorf("import is limited to %d jobs", maxImportRows)
+	}
+
+	fields := createJobRequestFields()
+	header := records[0]
+	var unknown []string
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := fields[header[i]]; !ok {
+			unknown = append(unknown, column)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, nil, fmt.Errorf("unknown CSV columns: %s", strings.Join(unknown, ", "))
+	}
+
+	requests := make([]CreateJobRequest, len(records)-1)
+	decodeErrors := make([][]string, len(records)-1)
+	for i, record := range records[1:] {
+		object := make(map[string]interface{}, len(record))
+		for j, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			value, err := csvCellValue(fields[header[j]], cell)
+			if err != nil {
+				decodeErrors[i] = append(decodeErrors[i], fmt.Sprintf("%s: %v", header[j], err))
+				continue
+			}
+			object[header[j]] = value
+		}
+
+		encoded, _ := json.Marshal(object)
+		if err := json.Unmarshal(encoded, &requests[i]); err != nil {
+			decodeErrors[i] = append(decodeErrors[i], err.Error())
+		}
+	}
+	return requests, decodeErrors, nil
+}
+
+// csvCellValue converts a cell to the JSON value of a field of type t.
+func csvCellValue(t reflect.Type, cell string) (interface{}, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Float64:
+		v, err := strconv.ParseFloat(strings.ReplaceAll(cell, ",", "."), 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return v, nil
+	case reflect.Int:
+		v, err := strconv.Atoi(cell)
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		return v, nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(cell)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return v, nil
+	default:
+		// Strings, enums and RFC 3339 times decode from JSON strings.
+		return cell, nil
+	}
+}
+
+// createJobRequestFields maps CreateJobRequest's JSON field names to their
+// types.
+func createJobRequestFields() map[string]reflect.Type {
+	t := reflect.TypeOf(CreateJobRequest{})
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+	return fields
+}
+
+// buildImportRows validates each decoded request like POST /api/jobs and
+// builds its job.
+func buildImportRows(requests []CreateJobRequest, decodeErrors [][]string, claims *jwt.Claims, now time.Time) []*importRow {
+	rows := make([]*importRow, len(requests))
+	for i := range requests {
+		row := &importRow{request: requests[i], errors: decodeErrors[i]}
+		rows[i] = row
+
+		if err := binding.Validator.ValidateStruct(&row.request); err != nil {
+			row.errors = append(row.errors, validationMessages(err)...)
+		}
+		if len(row.errors) > 0 {
+			continue
+		}
+
+		job, err := row.request.NewJob(claims.UserID, now)
+		if err != nil {
+			row.errors = []string{err.Error()}
+			continue
+		}
+		row.job = job
+	}
+	return rows
+}
+
+// validationMessages describes binding errors by JSON field name.
+func validationMessages(err error) []string {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return []string{err.Error()}
+	}
+
+	t := reflect.TypeOf(CreateJobRequest{})
+	messages := make([]string, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		name := fe.Field()
+		if field, ok := t.FieldByName(fe.StructField()); ok {
+			name = strings.Split(field.Tag.Get("json"), ",")[0]
+		}
+		if fe.Param() != "" {
+			messages[i] = fmt.Sprintf("%s failed the %s=%s rule", name, fe.Tag(), fe.Param())
+		} else {
+			messages[i] = fmt.Sprintf("%s failed the %s rule", name, fe.Tag())
+		}
+	}
+	return messages
+}
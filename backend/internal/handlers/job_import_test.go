@@ -0,0 +1,485 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newImportRequest builds a gin context posting body as-is with
+// contentType, authenticated as an admin.
+func newImportRequest(t *testing.T, query, contentType string, body []byte) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/jobs/import"+query, bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", contentType)
+	c.Set(middleware.UserContextKey, &jwt.Claims{UserID: uuid.New(), Role: models.RoleAdmin})
+	return c, w
+}
+
+// multipartFile encodes data as the "file" field of a multipart form.
+func multipartFile(t *testing.T, filename string, data []byte) (string, []byte) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return writer.FormDataContentType(), body.Bytes()
+}
+
+func TestDecodeImportCSV(t *testing.T) {
+	t.Run("should decode typed cells and leave empty cells unset", func(t *testing.T) {
+		data := "\xef\xbb\xbfTitle, description,location,type,salary_min,openings,salary_negotiable\n" +
+			"Desenvolvedor Go,APIs em Go,Niterói,remote,\"8000,50\",2,true\n" +
+			"Analista,Dados,Recife,onsite,,,\n"
+
+		requests, decodeErrors, err := decodeImportCSV([]byte(data))
+
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+		assert.Equal(t, "Desenvolvedor Go", requests[0].Title)
+		assert.Equal(t, models.JobTypeRemote, requests[0].Type)
+		require.NotNil(t, requests[0].SalaryMin)
+		assert.Equal(t, 8000.5, *requests[0].SalaryMin)
+		assert.Equal(t, 2, requests[0].Openings)
+		assert.True(t, requests[0].SalaryNegotiable)
+		assert.Nil(t, requests[1].SalaryMin)
+		assert.Zero(t, requests[1].Openings)
+		assert.Empty(t, decodeErrors[0])
+		assert.Empty(t, decodeErrors[1])
+	})
+
+	t.Run("should report cells of the wrong type on their row", func(t *testing.T) {
+		data := "title,salary_min,openings,salary_negotiable,expires_at\n" +
+			"Desenvolvedor Go,muito,1.5,talvez,amanhã\n"
+
+		requests, decodeErrors, err := decodeImportCSV([]byte(data))
+
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, []string{
+			"salary_min: must be a number",
+			"openings: must be a whole number",
+			"salary_negotiable: must be true or false",
+		}, decodeErrors[0][:3])
+		assert.Len(t, decodeErrors[0], 4)
+	})
+
+	t.Run("should refuse unknown columns", func(t *testing.T) {
+		_, _, err := decodeImportCSV([]byte("title,salario\nGo,1000\n"))
+
+		assert.EqualError(t, err, "unknown CSV columns: salario")
+	})
+
+	t.Run("should refuse a header without jobs", func(t *testing.T) {
+		_, _, err := decodeImportCSV([]byte("title,description\n"))
+
+		assert.EqualError(t, err, "CSV import needs a header and at least one job")
+	})
+
+	t.Run("should refuse malformed CSV", func(t *testing.T) {
+		_, _, err := decodeImportCSV([]byte("title,description\nGo\n"))
+
+		assert.ErrorContains(t, err, "invalid CSV")
+	})
+
+	t.Run("should refuse more rows than the limit", func(t *testing.T) {
+		data := "title\n" + strings.Repeat("Go\n", maxImportRows+1)
+
+		_, _, err := decodeImportCSV([]byte(data))
+
+		assert.EqualError(t, err, fmt.Sprintf("import is limited to %d jobs", maxImportRows))
+	})
+}
+
+func TestDecodeImportJSON(t *testing.T) {
+	t.Run("should decode a list of jobs", func(t *testing.T) {
+		requests, decodeErrors, err := decodeImportJSON([]byte(`[{"title":"Go"},{"title":"Rust"}]`))
+
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+		assert.Equal(t, "Rust", requests[1].Title)
+		assert.Empty(t, decodeErrors[0])
+		assert.Empty(t, decodeErrors[1])
+	})
+
+	t.Run("should decode a jobs object", func(t *testing.T) {
+		requests, _, err := decodeImportJSON([]byte(`{"jobs":[{"title":"Go"}]}`))
+
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "Go", requests[0].Title)
+	})
+
+	t.Run("should report rows that do not decode", func(t *testing.T) {
+		requests, decodeErrors, err := decodeImportJSON([]byte(`[{"title":"Go","salario":1},{"title":2},{"title":"Rust"}]`))
+
+		require.NoError(t, err)
+		require.Len(t, requests, 3)
+		assert.Len(t, decodeErrors[0], 1)
+		assert.Contains(t, decodeErrors[0][0], "unknown field")
+		assert.Len(t, decodeErrors[1], 1)
+		assert.Empty(t, decodeErrors[2])
+	})
+
+	t.Run("should refuse other documents", func(t *testing.T) {
+		for _, data := range []string{`{"title":"Go"}`, `"Go"`, `[`} {
+			_, _, err := decodeImportJSON([]byte(data))
+
+			assert.EqualError(t, err, "JSON import must be a list of jobs or an object with a jobs list", data)
+		}
+	})
+
+	t.Run("should refuse an empty list", func(t *testing.T) {
+		_, _, err := decodeImportJSON([]byte(`{"jobs":[]}`))
+
+		assert.EqualError(t, err, "import has no jobs")
+	})
+
+	t.Run("should refuse more jobs than the limit", func(t *testing.T) {
+		data := "[" + strings.TrimSuffix(strings.Repeat(`{"title":"Go"},`, maxImportRows+1), ",") + "]"
+
+		_, _, err := decodeImportJSON([]byte(data))
+
+		assert.EqualError(t, err, fmt.Sprintf("import is limited to %d jobs", maxImportRows))
+	})
+}
+
+func TestReadImportPayload(t *testing.T) {
+	t.Run("should take the format from the content type", func(t *testing.T) {
+		c, _ := newImportRequest(t, "", "text/csv; charset=utf-8", []byte("title\nGo\n"))
+
+		format, data, err := readImportPayload(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, "csv", format)
+		assert.Equal(t, "title\nGo\n", string(data))
+	})
+
+	t.Run("should let the format parameter override the content type", func(t *testing.T) {
+		c, _ := newImportRequest(t, "?format=csv", "text/plain", []byte("title\nGo\n"))
+
+		format, _, err := readImportPayload(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, "csv", format)
+	})
+
+	t.Run("should default to JSON", func(t *testing.T) {
+		c, _ := newImportRequest(t, "", "text/plain", []byte(`[{"title":"Go"}]`))
+
+		format, _, err := readImportPayload(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, "json", format)
+	})
+
+	t.Run("should read an uploaded file with its extension", func(t *testing.T) {
+		contentType, body := multipartFile(t, "vagas.CSV", []byte("title\nGo\n"))
+		c, _ := newImportRequest(t, "", contentType, body)
+
+		format, data, err := readImportPayload(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, "csv", format)
+		assert.Equal(t, "title\nGo\n", string(data))
+	})
+
+	t.Run("should require the file field in multipart forms", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		require.NoError(t, writer.WriteField("mode", "atomic"))
+		require.NoError(t, writer.Close())
+		c, _ := newImportRequest(t, "", writer.FormDataContentType(), body.Bytes())
+
+		_, _, err := readImportPayload(c)
+
+		assert.EqualError(t, err, "file is required")
+	})
+
+	t.Run("should refuse unknown formats", func(t *testing.T) {
+		c, _ := newImportRequest(t, "?format=xlsx", "application/json", []byte(`[]`))
+
+		_, _, err := readImportPayload(c)
+
+		assert.EqualError(t, err, "format must be one of csv, json")
+	})
+
+	t.Run("should refuse an empty body", func(t *testing.T) {
+		c, _ := newImportRequest(t, "", "application/json", []byte(" \n"))
+
+		_, _, err := readImportPayload(c)
+
+		assert.EqualError(t, err, "import file is empty")
+	})
+
+	t.Run("should refuse bodies over the size limit", func(t *testing.T) {
+		c, _ := newImportRequest(t, "", "text/csv", bytes.Repeat([]byte("a"), maxImportBytes+1))
+
+		_, _, err := readImportPayload(c)
+
+		assert.ErrorContains(t, err, "failed to read body")
+	})
+
+	t.Run("should refuse uploads over the size limit", func(t *testing.T) {
+		contentType, body := multipartFile(t, "vagas.csv", bytes.Repeat([]byte("a"), maxImportBytes+1))
+		c, _ := newImportRequest(t, "", contentType, body)
+
+		_, _, err := readImportPayload(c)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildImportRows(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	claims := &jwt.Claims{UserID: uuid.New(), Role: models.RoleAdmin}
+	valid := func() CreateJobRequest {
+		return CreateJobRequest{Title: "Desenvolvedor Go", Description: "APIs em Go", Location: "Niterói", Type: models.JobTypeRemote}
+	}
+
+	t.Run("should build a draft job from a valid row", func(t *testing.T) {
+		rows := buildImportRows([]CreateJobRequest{valid()}, make([][]string, 1), claims, now)
+
+		require.Len(t, rows, 1)
+		assert.Empty(t, rows[0].errors)
+		require.NotNil(t, rows[0].job)
+		assert.Equal(t, models.JobStatusDraft, rows[0].job.Status)
+		assert.Equal(t, claims.UserID, rows[0].job.RecruiterID)
+	})
+
+	t.Run("should report validation errors by JSON field", func(t *testing.T) {
+		req := valid()
+		req.Title = ""
+		req.Type = "presencial"
+
+		rows := buildImportRows([]CreateJobRequest{req}, make([][]string, 1), claims, now)
+
+		assert.Nil(t, rows[0].job)
+		assert.Equal(t, []string{
+			"title failed the required rule",
+			"type failed the oneof=remote onsite hybrid rule",
+		}, rows[0].errors)
+	})
+
+	t.Run("should report the rules checked when building the job", func(t *testing.T) {
+		req := valid()
+		expired := now.Add(-time.Hour)
+		req.ExpiresAt = &expired
+
+		rows := buildImportRows([]CreateJobRequest{req}, make([][]string, 1), claims, now)
+
+		assert.Nil(t, rows[0].job)
+		assert.Equal(t, []string{"expires_at must be in the future"}, rows[0].errors)
+	})
+
+	t.Run("should keep decode errors and not build the job", func(t *testing.T) {
+		rows := buildImportRows([]CreateJobRequest{valid()}, [][]string{{"openings: must be a whole number"}}, claims, now)
+
+		assert.Nil(t, rows[0].job)
+		assert.Equal(t, []string{"openings: must be a whole number"}, rows[0].errors)
+	})
+}
+
+func TestJobHandler_Import(t *testing.T) {
+	const jobs = `[
+		{"title":"Desenvolvedor Go","description":"APIs em Go","location":"Niterói","type":"remote"},
+		{"title":"Analista de Dados","description":"Dados","location":"Recife","type":"onsite"}
+	]`
+	const withInvalid = `[
+		{"title":"Desenvolvedor Go","description":"APIs em Go","location":"Niterói","type":"remote"},
+		{"title":"Analista de Dados","location":"Recife","type":"onsite"}
+	]`
+
+	expectCreateJob := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		expectRecordRevision(mock)
+	}
+	importJobs := func(t *testing.T, h *JobHandler, query, body string) (*httptest.ResponseRecorder, ImportJobsResponse) {
+		c, w := newImportRequest(t, query, "application/json", []byte(body))
+		h.Import(c)
+
+		var resp ImportJobsResponse
+		if w.Code != http.StatusBadRequest && w.Code != http.StatusInternalServerError {
+			testutil.ParseResponseBody(t, w, &resp)
+		}
+		return w, resp
+	}
+
+	t.Run("should only validate on a dry run", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		w, resp := importJobs(t, h, "?dry_run=true", withInvalid)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 2, resp.Total)
+		assert.Equal(t, 1, resp.Valid)
+		assert.Equal(t, 1, resp.Invalid)
+		assert.Zero(t, resp.Created)
+		assert.Equal(t, []string{"description failed the required rule"}, resp.Rows[1].Errors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should create every job in one transaction", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectBegin()
+		expectCreateJob(mock)
+		expectCreateJob(mock)
+		mock.ExpectCommit()
+
+		w, resp := importJobs(t, h, "", jobs)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 2, resp.Created)
+		assert.NotNil(t, resp.Rows[0].Job)
+		assert.NotNil(t, resp.Rows[1].Job)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should create nothing atomically when a row is invalid", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		w, resp := importJobs(t, h, "?mode=atomic", withInvalid)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Zero(t, resp.Created)
+		assert.Equal(t, 1, resp.Invalid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should fail the atomic import when the database does", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectBegin()
+		expectCreateJob(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnError(&pgconn.PgError{Code: "22001", Message: "value too long for type character varying(255)"})
+		mock.ExpectRollback()
+
+		w, _ := importJobs(t, h, "", jobs)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should create valid rows and report the rest per row", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectBegin()
+		expectCreateJob(mock)
+		mock.ExpectCommit()
+
+		w, resp := importJobs(t, h, "?mode=per_row", withInvalid)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, resp.Created)
+		require.NotNil(t, resp.Rows[0].Job)
+		assert.Equal(t, "Desenvolvedor Go", resp.Rows[0].Job.Title)
+		assert.Nil(t, resp.Rows[1].Job)
+		assert.Equal(t, []string{"description failed the required rule"}, resp.Rows[1].Errors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report why the database refused a row", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnError(&pgconn.PgError{Code: "22001", Message: "value too long for type character varying(255)"})
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		expectCreateJob(mock)
+		mock.ExpectCommit()
+
+		w, resp := importJobs(t, h, "?mode=per_row", jobs)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, []string{"value too long for type character varying(255)"}, resp.Rows[0].Errors)
+		assert.Nil(t, resp.Rows[0].Job)
+		assert.NotNil(t, resp.Rows[1].Job)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report transient failures per row", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnError(fmt.Errorf("connection reset by peer"))
+		mock.ExpectRollback()
+
+		w, resp := importJobs(t, h, "?mode=per_row", `[{"title":"Go","description":"APIs","location":"Recife","type":"remote"}]`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Zero(t, resp.Created)
+		assert.Equal(t, []string{"failed to create job: connection reset by peer"}, resp.Rows[0].Errors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject bad parameters", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		for _, query := range []string{"?dry_run=talvez", "?mode=parcial"} {
+			w, _ := importJobs(t, h, query, jobs)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject files that do not decode", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		w, _ := importJobs(t, h, "", `{"title":"Go"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "JSON import must be a list of jobs or an object with a jobs list", body["error"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
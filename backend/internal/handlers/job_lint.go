@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ledufranco/recruitment-system/internal/lint"
+	"github.com/ledufranco/recruitment-system/internal/models"
+)
+
+type LintJobRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Languages   []string `json:"languages"`
+}
+
+type LintJobResponse struct {
+	Findings []lint.Finding `json:"findings"`
+}
+
+// LintedJobResponse is a job with the non-inclusive wording found in it.
+// Findings are advisory and never block saving the job.
+type LintedJobResponse struct {
+	models.JobResponse
+	Lint []lint.Finding `json:"lint"`
+}
+
+// Lint godoc
+// @Summary      Revisar linguagem inclusiva
+// @Description  Aponta termos com viés de gênero, de idade ou excludentes no título e na descrição, em português e inglês, com a posição de cada trecho (em caracteres) e sugestões de alternativas. Sem `languages`, usa todas as listas de palavras.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body LintJobRequest true "Texto da vaga"
+// @Success      200 {object} LintJobResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Router       /jobs/lint [post]
+func (h *JobHandler) Lint(c *gin.Context) {
+	var req LintJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Title == "" && req.Description == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title or description is required"})
+		return
+	}
+
+	for _, language := range req.Languages {
+		if !h.linter.Supports(language) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported language " + language})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, LintJobResponse{Findings: h.lint(req.Title, req.Description, req.Languages...)})
+}
+
+// lint checks the title and description, tagging each finding with its
+// field.
+func (h *JobHandler) lint(title, description string, languages ...string) []lint.Finding {
+	findings := []lint.Finding{}
+	for _, field := range []struct{ name, text string }{{"title", title}, {"description", description}} {
+		for _, finding := range h.linter.Lint(field.text, languages...) {
+			finding.Field = field.name
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func (h *JobHandler) lintedResponse(job *models.Job) LintedJobResponse {
+	return LintedJobResponse{
+		JobResponse: job.ToResponse(false),
+		Lint:        h.lint(job.Title, job.Description),
+	}
+}
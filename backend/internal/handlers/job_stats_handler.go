@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	statsDateLayout   = "2006-01-02"
+	defaultStatsDays  = 30
+	maxStatsDays      = 366
+	maxStatsReferrers = 10
+)
+
+type JobStatsHandler struct {
+	jobRepo         *repository.JobRepository
+	viewRepo        *repository.JobViewRepository
+	applicationRepo *repository.ApplicationRepository
+	access          *JobAccess
+}
+
+func NewJobStatsHandler(
+	jobRepo *repository.JobRepository,
+	viewRepo *repository.JobViewRepository,
+	applicationRepo *repository.ApplicationRepository,
+	access *JobAccess,
+) *JobStatsHandler {
+	return &JobStatsHandler{
+		jobRepo:         jobRepo,
+		viewRepo:        viewRepo,
+		applicationRepo: applicationRepo,
+		access:          access,
+	}
+}
+
+// Stats godoc
+// @Summary      Estatísticas da vaga
+// @Description  Retorna o funil da vaga no período: visualizações (únicas por visitante e dia), visitantes únicos, candidaturas, taxa de conversão (candidaturas / visitantes únicos), principais origens e a evolução diária. As datas são dias UTC, inclusive; o padrão são os últimos 30 dias. Visualizações são gravadas em lote e podem levar alguns segundos para aparecer.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        from query string false "Data inicial (YYYY-MM-DD)"
+// @Param        to query string false "Data final (YYYY-MM-DD)"
+// @Success      200 {object} models.JobStats
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/stats [get]
+func (h *JobStatsHandler) Stats(c *gin.Context) {
+	from, to, err := statsRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	if !h.access.Authorize(c, job, models.CapabilityViewApplications, "You can only view stats for jobs you are on the team of") {
+		return
+	}
+
+	views, uniqueViewers, err := h.viewRepo.Totals(job.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job stats"})
+		return
+	}
+	dailyViews, err := h.viewRepo.DailyViews(job.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job stats"})
+		return
+	}
+	dailyApplications, err := h.applicationRepo.DailyCountsForJob(job.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job stats"})
+		return
+	}
+	referrers, err := h.viewRepo.TopReferrers(job.ID, from, to, maxStatsReferrers)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job stats"})
+		return
+	}
+
+	stats := models.JobStats{
+		JobID:         job.ID,
+		From:          from.Format(statsDateLayout),
+		To:            to.Format(statsDateLayout),
+		Views:         views,
+		UniqueViewers: uniqueViewers,
+		Referrers:     referrers,
+		Daily:         statsDays(from, to, dailyViews, dailyApplications),
+	}
+	for _, day := range stats.Daily {
+		stats.Applications += day.Applications
+	}
+	stats.ConversionRate = models.Conversion(stats.Applications, stats.UniqueViewers)
+	if stats.Referrers == nil {
+		stats.Referrers = []models.ReferrerCount{}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// Sources godoc
+// @Summary      Candidaturas da vaga por origem
+// @Description  Conta as candidaturas da vaga e as aprovadas por canal de origem no período (indicação, código ?ref=, utm_source ou domínio de origem; direct quando desconhecida), com a taxa de aprovação de cada canal. As datas são dias UTC, inclusive; o padrão são os últimos 30 dias.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        from query string false "Data inicial (YYYY-MM-DD)"
+// @Param        to query string false "Data final (YYYY-MM-DD)"
+// @Success      200 {object} models.SourceReport
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/sources [get]
+func (h *JobStatsHandler) Sources(c *gin.Context) {
+	from, to, err := statsRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+
+	if !h.access.Authorize(c, job, models.CapabilityViewApplications, "You can only view stats for jobs you are on the team of") {
+		return
+	}
+
+	counts, err := h.applicationRepo.CountsBySource(&job.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get source report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSourceReport(&job.ID, from.Format(statsDateLayout), to.Format(statsDateLayout), counts))
+}
+
+// SourceReport godoc
+// @Summary      Candidaturas por origem
+// @Description  Conta as candidaturas a todas as vagas e as aprovadas por canal de origem no período, com a taxa de aprovação de cada canal, para comparar os resultados de cada site de vagas. As datas são dias UTC, inclusive; o padrão são os últimos 30 dias.
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from query string false "Data inicial (YYYY-MM-DD)"
+// @Param        to query string false "Data final (YYYY-MM-DD)"
+// @Success      200 {object} models.SourceReport
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /reports/sources [get]
+func (h *JobStatsHandler) SourceReport(c *gin.Context) {
+	from, to, err := statsRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts, err := h.applicationRepo.CountsBySource(nil, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get source report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewSourceReport(nil, from.Format(statsDateLayout), to.Format(statsDateLayout), counts))
+}
+
+// statsRange parses the from and to query dates as UTC days. Missing dates
+// default to the last defaultStatsDays days ending today.
+func statsRange(fromParam, toParam string, now time.Time) (time.Time, time.Time, error) {
+	now = now.UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toParam != "" {
+		parsed, err := time.Parse(statsDateLayout, toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultStatsDays - 1))
+	if fromParam != "" {
+		parsed, err := time.Parse(statsDateLayout, fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	if to.Sub(from) >= maxStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("date range must not exceed 366 days")
+	}
+	return from, to, nil
+}
+
+// statsDays lists every day in [from, to] with its views and applications,
+// including days with neither.
+func statsDays(from, to time.Time, views, applications []repository.DailyCount) []models.JobStatsDay {
+	viewsByDay := make(map[string]int64, len(views))
+	for _, v := range views {
+		viewsByDay[v.Day.Format(statsDateLayout)] = v.Count
+	}
+	applicationsByDay := make(map[string]int64, len(applications))
+	for _, a := range applications {
+		applicationsByDay[a.Day.Format(statsDateLayout)] = a.Count
+	}
+
+	var days []models.JobStatsDay
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(statsDateLayout)
+		days = append(days, models.JobStatsDay{
+			Date:         date,
+			Views:        viewsByDay[date],
+			Applications: applicationsByDay[date],
+		})
+	}
+	return days
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+type JobTeamHandler struct {
+	jobRepo  *repository.JobRepository
+	teamRepo *repository.JobTeamRepository
+	userRepo *repository.UserRepository
+	access   *JobAccess
+}
+
+type AddTeamMemberRequest struct {
+	UserID uuid.UUID       `json:"user_id" binding:"required"`
+	Role   models.TeamRole `json:"role" binding:"required,oneof=owner recruiter hiring_manager interviewer"`
+}
+
+func NewJobTeamHandler(
+	jobRepo *repository.JobRepository,
+	teamRepo *repository.JobTeamRepository,
+	userRepo *repository.UserRepository,
+	access *JobAccess,
+) *JobTeamHandler {
+	return &JobTeamHandler{
+		jobRepo:  jobRepo,
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		access:   access,
+	}
+}
+
+// List godoc
+// @Summary      Listar equipe de contratação
+// @Description  Lista a equipe de contratação da vaga, incluindo quem a criou (equipe de contratação da vaga)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {array} models.JobTeamMemberResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/team [get]
+func (h *JobTeamHandler) List(c *gin.Context) {
+	job, ok := h.loadJob(c, models.CapabilityViewJob, "You can only view the team of jobs you are on the team of")
+	if !ok {
+		return
+	}
+
+	members, err := h.teamRepo.FindByJobID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get team"})
+		return
+	}
+
+	responses := make([]models.JobTeamMemberResponse, 0, len(members)+1)
+	creator := models.JobTeamMemberResponse{
+		UserID:    job.RecruiterID,
+		Role:      models.TeamRoleOwner,
+		Creator:   true,
+		CreatedAt: job.CreatedAt,
+	}
+	if job.Recruiter.ID != uuid.Nil {
+		userResp := job.Recruiter.ToResponse()
+		creator.User = &userResp
+	}
+	responses = append(responses, creator)
+	for _, member := range members {
+		responses = append(responses, member.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// Add godoc
+// @Summary      Adicionar membro à equipe
+// @Description  Adiciona um admin à equipe de contratação da vaga ou altera seu papel (apenas owners da equipe)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        request body AddTeamMemberRequest true "Membro e papel"
+// @Success      200 {object} models.JobTeamMemberResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/team [post]
+func (h *JobTeamHandler) Add(c *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, ok := h.loadJob(c, models.CapabilityManageTeam, "Only job owners can manage the team")
+	if !ok {
+		return
+	}
+
+	if req.UserID == job.RecruiterID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The job creator is always an owner"})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+	if user.Role != models.RoleAdmin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only admin users can join a hiring team"})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	member := &models.JobTeamMember{
+		JobID:     job.ID,
+		UserID:    user.ID,
+		Role:      req.Role,
+		AddedByID: &claims.UserID,
+	}
+	if err := h.teamRepo.Save(member); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save team member"})
+		return
+	}
+
+	member.User = *user
+	c.JSON(http.StatusOK, member.ToResponse())
+}
+
+// Remove godoc
+// @Summary      Remover membro da equipe
+// @Description  Remove um membro da equipe de contratação da vaga (apenas owners da equipe)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        userId path string true "User ID"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/team/{userId} [delete]
+func (h *JobTeamHandler) Remove(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	job, ok := h.loadJob(c, models.CapabilityManageTeam, "Only job owners can manage the team")
+	if !ok {
+		return
+	}
+
+	if userID == job.RecruiterID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The job creator cannot be removed from the team"})
+		return
+	}
+
+	removed, err := h.teamRepo.Remove(job.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove team member"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team member removed successfully"})
+}
+
+func (h *JobTeamHandler) loadJob(c *gin.Context, capability models.JobCapability, forbiddenMessage string) (*models.Job, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return nil, false
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return nil, false
+	}
+
+	if !h.access.Authorize(c, job, capability, forbiddenMessage) {
+		return nil, false
+	}
+
+	return job, true
+}
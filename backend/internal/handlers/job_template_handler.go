@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+type JobTemplateHandler struct {
+	templateRepo *repository.JobTemplateRepository
+}
+
+type CreateJobTemplateRequest struct {
+	Name       string                    `json:"name" binding:"required"`
+	Visibility models.TemplateVisibility `json:"visibility" binding:"omitempty,oneof=private company"`
+	JobContentRequest
+}
+
+type UpdateJobTemplateRequest struct {
+	Name       string                    `json:"name"`
+	Visibility models.TemplateVisibility `json:"visibility" binding:"omitempty,oneof=private company"`
+	JobContentRequest
+}
+
+func NewJobTemplateHandler(templateRepo *repository.JobTemplateRepository) *JobTemplateHandler {
+	return &JobTemplateHandler{templateRepo: templateRepo}
+}
+
+// Create godoc
+// @Summary      Criar modelo de vaga
+// @Description  Cria um modelo de vaga reutilizável, pessoal ou compartilhado com a empresa (apenas admin)
+// @Tags         job-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreateJobTemplateRequest true "Dados do modelo"
+// @Success      201 {object} models.JobTemplateResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /job-templates [post]
+func (h *JobTemplateHandler) Create(c *gin.Context) {
+	var req CreateJobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	content := &models.Job{}
+	applyJobContent(content, req.JobContentRequest)
+	if err := content.ValidateSalary(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := &models.JobTemplate{
+		OwnerID:    claims.UserID,
+		Visibility: req.Visibility,
+		Name:       req.Name,
+	}
+	if template.Visibility == "" {
+		template.Visibility = models.TemplateVisibilityPrivate
+	}
+	template.SetContent(content)
+
+	if err := h.templateRepo.Create(template); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template.ToResponse())
+}
+
+// List godoc
+// @Summary      Listar modelos de vaga
+// @Description  Lista os modelos do admin autenticado e os compartilhados com a empresa
+// @Tags         job-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.JobTemplateResponse
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /job-templates [get]
+func (h *JobTemplateHandler) List(c *gin.Context) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	templates, err := h.templateRepo.FindVisibleTo(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list templates"})
+		return
+	}
+
+	responses := make([]models.JobTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = template.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetByID godoc
+// @Summary      Obter modelo de vaga
+// @Description  Retorna um modelo de vaga visível para o admin autenticado
+// @Tags         job-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Template ID"
+// @Success      200 {object} models.JobTemplateResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /job-templates/{id} [get]
+func (h *JobTemplateHandler) GetByID(c *gin.Context) {
+	template, ok := h.loadTemplate(c, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, template.ToResponse())
+}
+
+// Update godoc
+// @Summary      Atualizar modelo de vaga
+// @Description  Atualiza um modelo de vaga (apenas o dono do modelo)
+// @Tags         job-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Template ID"
+// @Param        request body UpdateJobTemplateRequest true "Dados para atualização"
+// @Success      200 {object} models.JobTemplateResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /job-templates/{id} [put]
+func (h *JobTemplateHandler) Update(c *gin.Context) {
+	var req UpdateJobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, ok := h.loadTemplate(c, true)
+	if !ok {
+		return
+	}
+
+	if req.Name != "" {
+		template.Name = req.Name
+	}
+	if req.Visibility != "" {
+		template.Visibility = req.Visibility
+	}
+
+	content := template.NewJob(template.OwnerID)
+	applyJobContent(content, req.JobContentRequest)
+	if err := content.ValidateSalary(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template.SetContent(content)
+
+	if err := h.templateRepo.Update(template); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template.ToResponse())
+}
+
+// Delete godoc
+// @Summary      Deletar modelo de vaga
+// @Description  Remove um modelo de vaga (apenas o dono do modelo)
+// @Tags         job-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Template ID"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /job-templates/{id} [delete]
+func (h *JobTemplateHandler) Delete(c *gin.Context) {
+	template, ok := h.loadTemplate(c, true)
+	if !ok {
+		return
+	}
+
+	if err := h.templateRepo.Delete(template.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+}
+
+// loadTemplate loads the :id template, checking that the caller can see it
+// or, with mustOwn, that the caller owns it.
+func (h *JobTemplateHandler) loadTemplate(c *gin.Context, mustOwn bool) (*models.JobTemplate, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return nil, false
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	template, err := h.templateRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get template"})
+		return nil, false
+	}
+
+	if !template.VisibleTo(claims.UserID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return nil, false
+	}
+
+	if mustOwn && template.OwnerID != claims.UserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only modify your own templates"})
+		return nil, false
+	}
+
+	return template, true
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var templateColumns = []string{"id", "owner_id", "visibility", "name", "title", "description", "location", "type"}
+
+func templateRow(template *models.JobTemplate) *sqlmock.Rows {
+	return sqlmock.NewRows(templateColumns).AddRow(
+		template.ID, template.OwnerID, template.Visibility, template.Name,
+		template.Title, template.Description, template.Location, template.Type,
+	)
+}
+
+func expectFindTemplate(mock sqlmock.Sqlmock, template *models.JobTemplate) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_templates" WHERE id = $1`)).
+		WithArgs(template.ID).
+		WillReturnRows(templateRow(template))
+}
+
+func TestJobTemplateHandler(t *testing.T) {
+	ownerID := uuid.New()
+	newTemplate := func(visibility models.TemplateVisibility) *models.JobTemplate {
+		return &models.JobTemplate{
+			ID:          uuid.New(),
+			OwnerID:     ownerID,
+			Visibility:  visibility,
+			Name:        "Backend",
+			Title:       "Desenvolvedor Go",
+			Description: "APIs em Go",
+			Location:    "Recife, PE",
+			Type:        models.JobTypeRemote,
+		}
+	}
+	templateParam := func(template *models.JobTemplate) gin.Param {
+		return gin.Param{Key: "id", Value: template.ID.String()}
+	}
+
+	t.Run("should create a private template by default", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_templates"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		c, w := newRequest(t, http.MethodPost, "/api/job-templates",
+			gin.H{"name": "Backend", "title": "Desenvolvedor Go", "contract_type": "full_time"}, ownerID, models.RoleAdmin)
+		h.Create(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp models.JobTemplateResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.TemplateVisibilityPrivate, resp.Visibility)
+		assert.Equal(t, ownerID, resp.OwnerID)
+		assert.Equal(t, models.ContractTypeFullTime, resp.ContractType)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse an inverted salary range", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+
+		c, w := newRequest(t, http.MethodPost, "/api/job-templates",
+			gin.H{"name": "Backend", "salary_min": 9000, "salary_max": 5000}, ownerID, models.RoleAdmin)
+		h.Create(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should list own and company templates", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_templates" WHERE (owner_id = $1 OR visibility = $2) AND "job_templates"."deleted_at" IS NULL ORDER BY name ASC`)).
+			WithArgs(ownerID, models.TemplateVisibilityCompany).
+			WillReturnRows(templateRow(newTemplate(models.TemplateVisibilityPrivate)))
+
+		c, w := newRequest(t, http.MethodGet, "/api/job-templates", nil, ownerID, models.RoleAdmin)
+		h.List(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp []models.JobTemplateResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Len(t, resp, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should hide private templates of other admins", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+		template := newTemplate(models.TemplateVisibilityPrivate)
+
+		expectFindTemplate(mock, template)
+
+		c, w := newRequest(t, http.MethodGet, "/api/job-templates/"+template.ID.String(), nil, uuid.New(), models.RoleAdmin, templateParam(template))
+		h.GetByID(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should update the owner's template keeping unset fields", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+		template := newTemplate(models.TemplateVisibilityPrivate)
+
+		expectFindTemplate(mock, template)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "job_templates" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		c, w := newRequest(t, http.MethodPut, "/api/job-templates/"+template.ID.String(),
+			gin.H{"visibility": "company", "title": "Desenvolvedor Rust"}, ownerID, models.RoleAdmin, templateParam(template))
+		h.Update(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.JobTemplateResponse
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.TemplateVisibilityCompany, resp.Visibility)
+		assert.Equal(t, "Desenvolvedor Rust", resp.Title)
+		assert.Equal(t, "APIs em Go", resp.Description)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid changing another admin's company template", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+		template := newTemplate(models.TemplateVisibilityCompany)
+
+		expectFindTemplate(mock, template)
+
+		c, w := newRequest(t, http.MethodPut, "/api/job-templates/"+template.ID.String(),
+			gin.H{"title": "Desenvolvedor Rust"}, uuid.New(), models.RoleAdmin, templateParam(template))
+		h.Update(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should delete the owner's template", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewJobTemplateHandler(repository.NewJobTemplateRepository(db))
+		template := newTemplate(models.TemplateVisibilityPrivate)
+
+		expectFindTemplate(mock, template)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "job_templates" SET "deleted_at"=$1 WHERE id = $2`)).
+			WithArgs(sqlmock.AnyArg(), template.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		c, w := newRequest(t, http.MethodDelete, "/api/job-templates/"+template.ID.String(), nil, ownerID, models.RoleAdmin, templateParam(template))
+		h.Delete(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_CreateFromTemplate(t *testing.T) {
+	ownerID := uuid.New()
+	template := &models.JobTemplate{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Visibility:  models.TemplateVisibilityCompany,
+		Name:        "Backend",
+		Title:       "Desenvolvedor Go",
+		Description: "APIs em Go",
+		Location:    "Recife, PE",
+		Type:        models.JobTypeRemote,
+	}
+	fromTemplate := func(t *testing.T, h *JobHandler, userID uuid.UUID, body interface{}) (int, models.JobResponse) {
+		c, w := newRequest(t, http.MethodPost, "/api/jobs/from-template/"+template.ID.String(), body, userID, models.RoleAdmin,
+			gin.Param{Key: "templateId", Value: template.ID.String()})
+		h.CreateFromTemplate(c)
+
+		var resp models.JobResponse
+		if w.Code == http.StatusCreated {
+			testutil.ParseResponseBody(t, w, &resp)
+		}
+		return w.Code, resp
+	}
+
+	t.Run("should create a draft from a shared template with overrides", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		userID := uuid.New()
+
+		expectFindTemplate(mock, template)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		expectRecordRevision(mock)
+		mock.ExpectCommit()
+
+		code, resp := fromTemplate(t, h, userID, gin.H{"location": "Olinda, PE"})
+
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, userID, resp.RecruiterID)
+		assert.Equal(t, models.JobStatusDraft, resp.Status)
+		assert.Equal(t, "Desenvolvedor Go", resp.Title)
+		assert.Equal(t, "Olinda, PE", resp.Location)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should hide private templates of other admins", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		private := *template
+		private.Visibility = models.TemplateVisibilityPrivate
+
+		expectFindTemplate(mock, &private)
+
+		code, _ := fromTemplate(t, h, uuid.New(), nil)
+
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should require the fields the template leaves empty", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+		partial := *template
+		partial.Location = ""
+
+		expectFindTemplate(mock, &partial)
+
+		code, _ := fromTemplate(t, h, ownerID, nil)
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse an expiry in the past like job creation", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindTemplate(mock, template)
+
+		c, w := newRequest(t, http.MethodPost, "/api/jobs/from-template/"+template.ID.String(),
+			gin.H{"expires_at": "2020-01-01T00:00:00Z"}, ownerID, models.RoleAdmin,
+			gin.Param{Key: "templateId", Value: template.ID.String()})
+		h.CreateFromTemplate(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "expires_at must be in the future")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobHandler_Clone(t *testing.T) {
+	recruiterID := uuid.New()
+	source := &models.Job{
+		ID:          uuid.New(),
+		Title:       "Desenvolvedor Go",
+		Description: "APIs em Go.",
+		Location:    "Niterói, RJ",
+		Type:        models.JobTypeOnsite,
+		Status:      models.JobStatusClosed,
+		RecruiterID: recruiterID,
+		Openings:    2,
+		Slug:        "desenvolvedor-go",
+	}
+	clone := func(t *testing.T, h *JobHandler, userID uuid.UUID, body interface{}) (int, string) {
+		c, w := newRequest(t, http.MethodPost, "/api/jobs/"+source.ID.String()+"/clone", body, userID, models.RoleAdmin,
+			gin.Param{Key: "id", Value: source.ID.String()})
+		h.Clone(c)
+		return w.Code, w.Body.String()
+	}
+
+	t.Run("should copy the job into a new draft", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindJob(mock, source)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("desenvolvedor-go"))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		expectRecordRevision(mock)
+		mock.ExpectCommit()
+
+		code, body := clone(t, h, recruiterID, gin.H{"title": "Desenvolvedor Go Sênior"})
+
+		require.Equal(t, http.StatusCreated, code)
+		assert.Contains(t, body, `"status":"draft"`)
+		assert.Contains(t, body, `"title":"Desenvolvedor Go Sênior"`)
+		assert.Contains(t, body, `"slug":"desenvolvedor-go-senior"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse an application deadline in the past like job creation", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindJob(mock, source)
+
+		code, body := clone(t, h, recruiterID, gin.H{"application_deadline": "2020-01-01T00:00:00Z"})
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Contains(t, body, "application_deadline must be in the future")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid admins outside the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobHandler(t, db, testConfig())
+
+		expectFindJob(mock, source)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_team_members"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "user_id", "role"}))
+
+		code, _ := clone(t, h, uuid.New(), nil)
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"gorm.io/gorm"
+)
+
+type JobTranslationHandler struct {
+	jobRepo         *repository.JobRepository
+	translationRepo *repository.JobTranslationRepository
+	access          *JobAccess
+}
+
+type SetJobTranslationRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description" binding:"required"`
+}
+
+func NewJobTranslationHandler(
+	jobRepo *repository.JobRepository,
+	translationRepo *repository.JobTranslationRepository,
+	access *JobAccess,
+) *JobTranslationHandler {
+	return &JobTranslationHandler{
+		jobRepo:         jobRepo,
+		translationRepo: translationRepo,
+		access:          access,
+	}
+}
+
+// List godoc
+// @Summary      Listar traduções da vaga
+// @Description  Lista as traduções de título e descrição da vaga (equipe de contratação da vaga)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Success      200 {array} models.JobTranslation
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/translations [get]
+func (h *JobTranslationHandler) List(c *gin.Context) {
+	job, ok := h.loadJob(c, models.CapabilityViewJob, "You can only view translations of jobs you are on the team of")
+	if !ok {
+		return
+	}
+
+	translations, err := h.translationRepo.FindByJobID(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// Set godoc
+// @Summary      Definir tradução da vaga
+// @Description  Cria ou substitui o título e a descrição da vaga em um idioma (pt-BR, es, en). O idioma original da vaga é definido pelo campo locale da própria vaga (owner ou recruiter da equipe).
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        locale path string true "Idioma (pt-BR, es, en)"
+// @Param        request body SetJobTranslationRequest true "Conteúdo traduzido"
+// @Success      200 {object} models.JobTranslation
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/translations/{locale} [put]
+func (h *JobTranslationHandler) Set(c *gin.Context) {
+	var req SetJobTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	locale, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	job, ok := h.loadJob(c, models.CapabilityEditJob, "You can only translate jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	if locale == job.ContentLocale() {
+		c.JSON(http.StatusConflict, gin.H{"error": "The job is already written in this locale; update the job instead"})
+		return
+	}
+
+	translation := &models.JobTranslation{
+		JobID:       job.ID,
+		Locale:      locale,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	if err := h.translationRepo.Save(translation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// Delete godoc
+// @Summary      Remover tradução da vaga
+// @Description  Remove a tradução da vaga em um idioma (owner ou recruiter da equipe)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Job ID"
+// @Param        locale path string true "Idioma (pt-BR, es, en)"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /jobs/{id}/translations/{locale} [delete]
+func (h *JobTranslationHandler) Delete(c *gin.Context) {
+	locale, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	job, ok := h.loadJob(c, models.CapabilityEditJob, "You can only translate jobs you recruit for")
+	if !ok {
+		return
+	}
+
+	removed, err := h.translationRepo.Delete(job.ID, locale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete translation"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Translation deleted successfully"})
+}
+
+func (h *JobTranslationHandler) loadJob(c *gin.Context, capability models.JobCapability, forbiddenMessage string) (*models.Job, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return nil, false
+	}
+
+	job, err := h.jobRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return nil, false
+	}
+
+	if !h.access.Authorize(c, job, capability, forbiddenMessage) {
+		return nil, false
+	}
+
+	return job, true
+}
+
+// localeParam reads the :locale path parameter, which must name a supported
+// locale exactly.
+func localeParam(c *gin.Context) (models.Locale, bool) {
+	locale := models.Locale(c.Param("locale"))
+	for _, supported := range models.SupportedLocales() {
+		if locale == supported {
+			return locale, true
+		}
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": models.ErrUnsupportedLocale.Error()})
+	return "", false
+}
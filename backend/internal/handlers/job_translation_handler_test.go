@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestJobTranslationHandler(db *gorm.DB) *JobTranslationHandler {
+	return NewJobTranslationHandler(
+		repository.NewJobRepository(db),
+		repository.NewJobTranslationRepository(db),
+		NewJobAccess(repository.NewJobTeamRepository(db)),
+	)
+}
+
+func TestJobTranslationHandler(t *testing.T) {
+	recruiterID := uuid.New()
+	job := &models.Job{
+		ID:          uuid.New(),
+		Title:       "Desenvolvedor Go",
+		Description: "APIs em Go.",
+		Location:    "Niterói, RJ",
+		Type:        models.JobTypeRemote,
+		Status:      models.JobStatusOpen,
+		RecruiterID: recruiterID,
+		Openings:    1,
+		Slug:        "desenvolvedor-go",
+	}
+	params := func(locale string) []gin.Param {
+		return []gin.Param{{Key: "id", Value: job.ID.String()}, {Key: "locale", Value: locale}}
+	}
+	expectTeamRole := func(mock sqlmock.Sqlmock, userID uuid.UUID, role models.TeamRole) {
+		rows := sqlmock.NewRows([]string{"job_id", "user_id", "role"})
+		if role != "" {
+			rows.AddRow(job.ID, userID, role)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_team_members" WHERE job_id = $1 AND user_id = $2`)).
+			WithArgs(job.ID, userID).
+			WillReturnRows(rows)
+	}
+
+	t.Run("should list translations for the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+		interviewer := uuid.New()
+
+		expectFindJob(mock, job)
+		expectTeamRole(mock, interviewer, models.TeamRoleInterviewer)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_translations" WHERE job_id = $1 ORDER BY locale`)).
+			WithArgs(job.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "locale", "title", "description"}).
+				AddRow(job.ID, models.LocaleEs, "Desarrollador Go", "APIs en Go."))
+
+		c, w := newRequest(t, http.MethodGet, "/api/jobs/"+job.ID.String()+"/translations", nil, interviewer, models.RoleAdmin, params("")...)
+		h.List(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp []models.JobTranslation
+		testutil.ParseResponseBody(t, w, &resp)
+		require.Len(t, resp, 1)
+		assert.Equal(t, "Desarrollador Go", resp[0].Title)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid listing outside the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+		outsider := uuid.New()
+
+		expectFindJob(mock, job)
+		expectTeamRole(mock, outsider, "")
+
+		c, w := newRequest(t, http.MethodGet, "/api/jobs/"+job.ID.String()+"/translations", nil, outsider, models.RoleAdmin, params("")...)
+		h.List(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	set := func(t *testing.T, h *JobTranslationHandler, userID uuid.UUID, locale string, body interface{}) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodPut, "/api/jobs/"+job.ID.String()+"/translations/"+locale, body, userID, models.RoleAdmin, params(locale)...)
+		h.Set(c)
+		return w
+	}
+	translation := gin.H{"title": "Desarrollador Go", "description": "APIs en Go."}
+
+	t.Run("should save a translation for the job's recruiter", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "job_translations"`)).
+			WithArgs(job.ID, models.LocaleEs, "Desarrollador Go", "APIs en Go.", "APIs en Go.", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		w := set(t, h, recruiterID, "es", translation)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.JobTranslation
+		testutil.ParseResponseBody(t, w, &resp)
+		assert.Equal(t, models.LocaleEs, resp.Locale)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse to translate into the job's own locale", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		expectFindJob(mock, job)
+
+		w := set(t, h, recruiterID, "pt-BR", translation)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should refuse unsupported locales before loading the job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		w := set(t, h, recruiterID, "fr", translation)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), models.ErrUnsupportedLocale.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should require a title and description", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		w := set(t, h, recruiterID, "es", gin.H{"title": "Desarrollador Go"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid interviewers from translating", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+		interviewer := uuid.New()
+
+		expectFindJob(mock, job)
+		expectTeamRole(mock, interviewer, models.TeamRoleInterviewer)
+
+		w := set(t, h, interviewer, "es", translation)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report a missing job", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+			WithArgs(job.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		w := set(t, h, recruiterID, "es", translation)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	remove := func(t *testing.T, h *JobTranslationHandler, userID uuid.UUID, locale string) *httptest.ResponseRecorder {
+		c, w := newRequest(t, http.MethodDelete, "/api/jobs/"+job.ID.String()+"/translations/"+locale, nil, userID, models.RoleAdmin, params(locale)...)
+		h.Delete(c)
+		return w
+	}
+
+	t.Run("should delete a translation", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "job_translations" WHERE job_id = $1 AND locale = $2`)).
+			WithArgs(job.ID, models.LocaleEn).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		w := remove(t, h, recruiterID, "en")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report a missing translation", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+
+		expectFindJob(mock, job)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "job_translations"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		w := remove(t, h, recruiterID, "en")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should forbid deleting outside the hiring team", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := newTestJobTranslationHandler(db)
+		outsider := uuid.New()
+
+		expectFindJob(mock, job)
+		expectTeamRole(mock, outsider, "")
+
+		w := remove(t, h, outsider, "en")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
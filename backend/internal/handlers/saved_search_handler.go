@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/middleware"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/ledufranco/recruitment-system/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+type SavedSearchHandler struct {
+	searchRepo *repository.SavedSearchRepository
+}
+
+type CreateSavedSearchRequest struct {
+	Name      string                `json:"name" binding:"required"`
+	Frequency models.AlertFrequency `json:"frequency" binding:"omitempty,oneof=instant daily weekly"`
+	Criteria  models.SearchCriteria `json:"criteria"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name          string                 `json:"name"`
+	Frequency     models.AlertFrequency  `json:"frequency" binding:"omitempty,oneof=instant daily weekly"`
+	Criteria      *models.SearchCriteria `json:"criteria"`
+	AlertsEnabled *bool                  `json:"alerts_enabled"`
+}
+
+func NewSavedSearchHandler(searchRepo *repository.SavedSearchRepository) *SavedSearchHandler {
+	return &SavedSearchHandler{searchRepo: searchRepo}
+}
+
+// Create godoc
+// @Summary      Salvar busca de vagas
+// @Description  Salva uma combinação de filtros de /jobs com um nome e a frequência dos alertas por email (apenas candidatos)
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreateSavedSearchRequest true "Busca a salvar"
+// @Success      201 {object} models.SavedSearchResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      422 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches [post]
+func (h *SavedSearchHandler) Create(c *gin.Context) {
+	var req CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.Criteria.Validate(); err != nil {
+		if errors.Is(err, models.ErrUnsupportedLocation) {
+			unsupportedLocation(c, req.Criteria.Near)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	search := &models.SavedSearch{
+		CandidateID:   claims.UserID,
+		Name:          req.Name,
+		Criteria:      req.Criteria,
+		Frequency:     req.Frequency,
+		AlertsEnabled: true,
+		// Only jobs published from now on trigger alerts.
+		LastCheckedAt: time.Now(),
+	}
+	if search.Frequency == "" {
+		search.Frequency = models.AlertFrequencyDaily
+	}
+
+	if err := h.searchRepo.Create(search); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save search"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, search.ToResponse())
+}
+
+// List godoc
+// @Summary      Listar buscas salvas
+// @Description  Lista as buscas salvas do candidato autenticado
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.SavedSearchResponse
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches [get]
+func (h *SavedSearchHandler) List(c *gin.Context) {
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	searches, err := h.searchRepo.FindByCandidateID(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list saved searches"})
+		return
+	}
+
+	responses := make([]models.SavedSearchResponse, len(searches))
+	for i, search := range searches {
+		responses[i] = search.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// Update godoc
+// @Summary      Atualizar busca salva
+// @Description  Atualiza nome, filtros, frequência ou ativa/desativa os alertas de uma busca salva
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Saved search ID"
+// @Param        request body UpdateSavedSearchRequest true "Dados para atualização"
+// @Success      200 {object} models.SavedSearchResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      422 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches/{id} [put]
+func (h *SavedSearchHandler) Update(c *gin.Context) {
+	var req UpdateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	search, ok := h.loadSearch(c)
+	if !ok {
+		return
+	}
+
+	if req.Criteria != nil {
+		if err := req.Criteria.Validate(); err != nil {
+			if errors.Is(err, models.ErrUnsupportedLocation) {
+				unsupportedLocation(c, req.Criteria.Near)
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		search.Criteria = *req.Criteria
+	}
+	if req.Name != "" {
+		search.Name = req.Name
+	}
+	if req.Frequency != "" {
+		search.Frequency = req.Frequency
+	}
+	if req.AlertsEnabled != nil {
+		// Re-enabling alerts does not replay the jobs published while they
+		// were off.
+		if *req.AlertsEnabled && !search.AlertsEnabled {
+			search.LastCheckedAt = time.Now()
+		}
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+
+	if err := h.searchRepo.Update(search); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, search.ToResponse())
+}
+
+// Delete godoc
+// @Summary      Remover busca salva
+// @Description  Remove uma busca salva e seus alertas
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Saved search ID"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches/{id} [delete]
+func (h *SavedSearchHandler) Delete(c *gin.Context) {
+	search, ok := h.loadSearch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.searchRepo.Delete(search.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved search"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Saved search deleted successfully"})
+}
+
+// unsubscribePage is the page behind the link in alert emails. Following
+// the link only shows it; the alerts are turned off by submitting the form,
+// so link scanners that prefetch URLs cannot unsubscribe anyone.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Cancelar alertas de vagas</title>
+</head>
+<body>
+{{if .Done}}
+<p>Você não receberá mais alertas da busca <strong>{{.Name}}</strong>.</p>
+{{else}}
+<p>Deseja parar de receber alertas por email da busca <strong>{{.Name}}</strong>?</p>
+<form method="post">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<button type="submit">Cancelar alertas</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+// UnsubscribePage godoc
+// @Summary      Confirmar cancelamento de alertas
+// @Description  Página aberta pelo link do email de alertas, sem autenticação. Apenas pede a confirmação, que é enviada por POST para o mesmo endereço; nada é alterado nesta requisição.
+// @Tags         saved-searches
+// @Produce      html
+// @Param        token path string true "Token de cancelamento"
+// @Success      200 {string} string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches/unsubscribe/{token} [get]
+func (h *SavedSearchHandler) UnsubscribePage(c *gin.Context) {
+	search, ok := h.loadUnsubscribeSearch(c)
+	if !ok {
+		return
+	}
+
+	renderUnsubscribePage(c, search, false)
+}
+
+// Unsubscribe godoc
+// @Summary      Cancelar alertas de uma busca salva
+// @Description  Desativa os alertas por email de uma busca salva, sem autenticação. Recebe o formulário da página de confirmação e o POST de um clique dos clientes de email (List-Unsubscribe-Post). Responde com HTML quando o cliente aceita HTML.
+// @Tags         saved-searches
+// @Produce      json,html
+// @Param        token path string true "Token de cancelamento"
+// @Success      200 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /saved-searches/unsubscribe/{token} [post]
+func (h *SavedSearchHandler) Unsubscribe(c *gin.Context) {
+	search, ok := h.loadUnsubscribeSearch(c)
+	if !ok {
+		return
+	}
+
+	if search.AlertsEnabled {
+		search.AlertsEnabled = false
+		if err := h.searchRepo.Update(search); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unsubscribe"})
+			return
+		}
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderUnsubscribePage(c, search, true)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed from alerts for " + search.Name})
+}
+
+// loadUnsubscribeSearch loads the saved search the :token unsubscribe link
+// belongs to.
+func (h *SavedSearchHandler) loadUnsubscribeSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	search, err := h.searchRepo.FindByUnsubscribeToken(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get saved search"})
+		return nil, false
+	}
+	return search, true
+}
+
+func renderUnsubscribePage(c *gin.Context, search *models.SavedSearch, done bool) {
+	var body bytes.Buffer
+	if err := unsubscribePage.Execute(&body, gin.H{"Name": search.Name, "Done": done}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render page"})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body.Bytes())
+}
+
+// loadSearch loads the :id saved search, checking that it belongs to the
+// caller. Other candidates' searches read as not found.
+func (h *SavedSearchHandler) loadSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return nil, false
+	}
+
+	userClaims, _ := c.Get(middleware.UserContextKey)
+	claims := userClaims.(*jwt.Claims)
+
+	search, err := h.searchRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get saved search"})
+		return nil, false
+	}
+
+	if search.CandidateID != claims.UserID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return nil, false
+	}
+
+	return search, true
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchHandler_Unsubscribe(t *testing.T) {
+	const token = "a1b2c3"
+	tokenParam := gin.Param{Key: "token", Value: token}
+
+	expectFindByToken := func(mock sqlmock.Sqlmock, alertsEnabled bool) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "saved_searches" WHERE unsubscribe_token = $1`)).
+			WithArgs(token).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "candidate_id", "name", "alerts_enabled", "unsubscribe_token"}).
+				AddRow(uuid.New(), uuid.New(), "Go em Recife", alertsEnabled, token))
+	}
+	expectDisableAlerts := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "saved_searches" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	t.Run("should only ask for confirmation when the link is opened", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewSavedSearchHandler(repository.NewSavedSearchRepository(db))
+
+		expectFindByToken(mock, true)
+
+		c, w := newRequest(t, http.MethodGet, "/api/saved-searches/unsubscribe/"+token, nil, uuid.Nil, "", tokenParam)
+		h.UnsubscribePage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), `<form method="post">`)
+		assert.Contains(t, w.Body.String(), "Go em Recife")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report unknown links as not found", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewSavedSearchHandler(repository.NewSavedSearchRepository(db))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "saved_searches" WHERE unsubscribe_token = $1`)).
+			WithArgs(token).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		c, w := newRequest(t, http.MethodGet, "/api/saved-searches/unsubscribe/"+token, nil, uuid.Nil, "", tokenParam)
+		h.UnsubscribePage(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should disable the alerts on a one-click POST", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewSavedSearchHandler(repository.NewSavedSearchRepository(db))
+
+		expectFindByToken(mock, true)
+		expectDisableAlerts(mock)
+
+		c, w := newRequest(t, http.MethodPost, "/api/saved-searches/unsubscribe/"+token, nil, uuid.Nil, "", tokenParam)
+		h.Unsubscribe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Unsubscribed from alerts for Go em Recife")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should answer the confirmation form with a page", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewSavedSearchHandler(repository.NewSavedSearchRepository(db))
+
+		expectFindByToken(mock, true)
+		expectDisableAlerts(mock)
+
+		c, w := newRequest(t, http.MethodPost, "/api/saved-searches/unsubscribe/"+token, nil, uuid.Nil, "", tokenParam)
+		c.Request.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+		h.Unsubscribe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), "não receberá mais alertas")
+		assert.NotContains(t, w.Body.String(), "<form")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not write when alerts are already disabled", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		h := NewSavedSearchHandler(repository.NewSavedSearchRepository(db))
+
+		expectFindByToken(mock, false)
+
+		c, w := newRequest(t, http.MethodPost, "/api/saved-searches/unsubscribe/"+token, nil, uuid.Nil, "", tokenParam)
+		h.Unsubscribe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+type SkillHandler struct {
+	skillRepo *repository.SkillRepository
+}
+
+type CreateSkillRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+func NewSkillHandler(skillRepo *repository.SkillRepository) *SkillHandler {
+	return &SkillHandler{skillRepo: skillRepo}
+}
+
+// List godoc
+// @Summary      Listar habilidades
+// @Description  Lista as habilidades da taxonomia com a quantidade de vagas abertas que pedem cada uma
+// @Tags         skills
+// @Accept       json
+// @Produce      json
+// @Success      200 {array} models.SkillResponse
+// @Failure      500 {object} map[string]string
+// @Router       /skills [get]
+func (h *SkillHandler) List(c *gin.Context) {
+	counts, err := h.skillRepo.FindAllWithOpenJobs(time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list skills"})
+		return
+	}
+
+	responses := make([]models.SkillResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = count.Skill.ToResponse(count.OpenJobs)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// Create godoc
+// @Summary      Criar habilidade
+// @Description  Adiciona uma habilidade à taxonomia, com apelidos opcionais (ex.: "golang" para "Go") (apenas admin)
+// @Tags         skills
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreateSkillRequest true "Dados da habilidade"
+// @Success      201 {object} models.SkillResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /skills [post]
+func (h *SkillHandler) Create(c *gin.Context) {
+	var req CreateSkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill := &models.Skill{Name: strings.TrimSpace(req.Name)}
+	if skill.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	for _, alias := range req.Aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			skill.Aliases = append(skill.Aliases, models.SkillAlias{Alias: alias})
+		}
+	}
+
+	if err := h.skillRepo.Create(skill); err != nil {
+		if errors.Is(err, repository.ErrSkillExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create skill"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, skill.ToResponse(0))
+}
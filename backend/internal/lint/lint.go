@@ -0,0 +1,303 @@
+// Package lint flags gender-coded, age-biased and exclusionary wording in
+// job postings and suggests alternatives. Phrases come from word lists, one
+// per language, bundled with the package and extensible with extra files.
+package lint
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+)
+
+type Category string
+
+const (
+	CategoryGenderCoded  Category = "gender_coded"
+	CategoryAgeBiased    Category = "age_biased"
+	CategoryExclusionary Category = "exclusionary"
+)
+
+var categoryMessages = map[Category]string{
+	CategoryGenderCoded:  "Gender-coded wording may discourage some candidates from applying",
+	CategoryAgeBiased:    "Age-biased wording may discourage some candidates from applying",
+	CategoryExclusionary: "Exclusionary wording may discriminate against candidates",
+}
+
+//go:embed wordlists/*.json
+var bundled embed.FS
+
+// WordList is a set of phrases to flag in one language. Phrases are matched
+// word by word ignoring case, accents and punctuation; a word ending in "*"
+// matches any word starting with it, which covers inflections such as
+// "agressivo" and "agressiva".
+type WordList struct {
+	Language string  `json:"language"`
+	Entries  []Entry `json:"entries"`
+}
+
+// Entry is a flagged phrase. Exceptions are longer phrases starting with
+// the same words that are fine, like "jovem aprendiz".
+type Entry struct {
+	Phrase      string   `json:"phrase"`
+	Category    Category `json:"category"`
+	Message     string   `json:"message,omitempty"`
+	Suggestions []string `json:"suggestions"`
+	Exceptions  []string `json:"exceptions,omitempty"`
+}
+
+// Finding is a flagged phrase in a text. Start and End are character (not
+// byte) offsets of the phrase in the text as written.
+type Finding struct {
+	Field       string   `json:"field,omitempty"`
+	Start       int      `json:"start"`
+	End         int      `json:"end"`
+	Text        string   `json:"text"`
+	Category    Category `json:"category"`
+	Language    string   `json:"language"`
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// Linter checks texts against its word lists.
+type Linter struct {
+	rules []rule
+}
+
+type rule struct {
+	language   string
+	entry      Entry
+	words      []pattern
+	exceptions [][]pattern
+}
+
+// pattern is one normalized word of a phrase.
+type pattern struct {
+	word   string
+	prefix bool
+}
+
+// token is a word of the linted text with its character offsets.
+type token struct {
+	word       string
+	start, end int
+}
+
+// Load returns a linter with the bundled word lists plus the lists in the
+// given JSON files.
+func Load(paths ...string) (*Linter, error) {
+	var lists []WordList
+
+	entries, err := bundled.ReadDir("wordlists")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := bundled.ReadFile("wordlists/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		list, err := parseWordList(data, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read word list: %w", err)
+		}
+		list, err := parseWordList(data, path)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	return New(lists...)
+}
+
+func parseWordList(data []byte, name string) (WordList, error) {
+	var list WordList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return list, fmt.Errorf("invalid word list %s: %w", name, err)
+	}
+	if list.Language == "" {
+		return list, fmt.Errorf("invalid word list %s: missing language", name)
+	}
+	return list, nil
+}
+
+// New returns a linter for the given word lists.
+func New(lists ...WordList) (*Linter, error) {
+	l := &Linter{}
+	for _, list := range lists {
+		for _, entry := range list.Entries {
+			if _, ok := categoryMessages[entry.Category]; !ok {
+				return nil, fmt.Errorf("invalid word list %s: phrase %q has unknown category %q", list.Language, entry.Phrase, entry.Category)
+			}
+			words := compile(entry.Phrase)
+			if len(words) == 0 {
+				return nil, fmt.Errorf("invalid word list %s: empty phrase", list.Language)
+			}
+			r := rule{language: list.Language, entry: entry, words: words}
+			for _, exception := range entry.Exceptions {
+				r.exceptions = append(r.exceptions, compile(exception))
+			}
+			l.rules = append(l.rules, r)
+		}
+	}
+
+	// Longer phrases win, so "native english speaker" is reported rather
+	// than "native speaker" inside it.
+	sort.SliceStable(l.rules, func(i, j int) bool {
+		return len(l.rules[i].words) > len(l.rules[j].words)
+	})
+	return l, nil
+}
+
+// Languages lists the languages the linter has word lists for.
+func (l *Linter) Languages() []string {
+	seen := make(map[string]bool)
+	var languages []string
+	for _, r := range l.rules {
+		if !seen[r.language] {
+			seen[r.language] = true
+			languages = append(languages, r.language)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Supports reports whether the linter has a word list for language.
+func (l *Linter) Supports(language string) bool {
+	return contains(l.Languages(), language)
+}
+
+// Lint returns the flagged phrases of text in order. languages restricts the
+// word lists used; empty uses all of them, since postings often mix
+// languages.
+func (l *Linter) Lint(text string, languages ...string) []Finding {
+	runes := []rune(text)
+	tokens := tokenize(runes)
+
+	findings := []Finding{}
+	for i := 0; i < len(tokens); {
+		r, ok := l.match(tokens[i:], languages)
+		if !ok {
+			i++
+			continue
+		}
+
+		first, last := tokens[i], tokens[i+len(r.words)-1]
+		message := r.entry.Message
+		if message == "" {
+			message = categoryMessages[r.entry.Category]
+		}
+		findings = append(findings, Finding{
+			Start:       first.start,
+			End:         last.end,
+			Text:        string(runes[first.start:last.end]),
+			Category:    r.entry.Category,
+			Language:    r.language,
+			Message:     message,
+			Suggestions: r.entry.Suggestions,
+		})
+		i += len(r.words)
+	}
+	return findings
+}
+
+// match returns the first rule whose phrase starts at tokens[0].
+func (l *Linter) match(tokens []token, languages []string) (rule, bool) {
+	for _, r := range l.rules {
+		if len(languages) > 0 && !contains(languages, r.language) {
+			continue
+		}
+		if !matches(tokens, r.words) {
+			continue
+		}
+		excepted := false
+		for _, exception := range r.exceptions {
+			if matches(tokens, exception) {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+func matches(tokens []token, words []pattern) bool {
+	if len(words) > len(tokens) {
+		return false
+	}
+	for i, p := range words {
+		if tokens[i].word != p.word && !(p.prefix && strings.HasPrefix(tokens[i].word, p.word)) {
+			return false
+		}
+	}
+	return true
+}
+
+// compile splits a phrase into normalized word patterns.
+func compile(phrase string) []pattern {
+	var words []pattern
+	for _, field := range strings.Fields(phrase) {
+		prefix := strings.HasSuffix(field, "*")
+		for _, word := range strings.Fields(utils.NormalizeText(strings.TrimSuffix(field, "*"))) {
+			words = append(words, pattern{word: word})
+		}
+		if prefix && len(words) > 0 {
+			words[len(words)-1].prefix = true
+		}
+	}
+	return words
+}
+
+// tokenize splits text into words of letters and digits, normalized like
+// the word lists, keeping their character offsets.
+func tokenize(runes []rune) []token {
+	var tokens []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		for _, word := range strings.Fields(utils.NormalizeText(string(runes[start:end]))) {
+			tokens = append(tokens, token{word: word, start: start, end: end})
+		}
+		start = -1
+	}
+	for i, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(runes))
+	return tokens
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinter_Lint(t *testing.T) {
+	linter, err := Load()
+	require.NoError(t, err)
+
+	t.Run("should flag phrases with their character spans", func(t *testing.T) {
+		text := "Procuramos um desenvolvedor jovem e dinâmico."
+
+		findings := linter.Lint(text)
+
+		require.Len(t, findings, 3)
+		assert.Equal(t, "um desenvolvedor", findings[0].Text)
+		assert.Equal(t, CategoryGenderCoded, findings[0].Category)
+		assert.Equal(t, "jovem", findings[1].Text)
+		assert.Equal(t, 28, findings[1].Start)
+		assert.Equal(t, 33, findings[1].End)
+		assert.Equal(t, CategoryAgeBiased, findings[1].Category)
+		assert.Equal(t, "pt", findings[1].Language)
+		assert.NotEmpty(t, findings[1].Suggestions)
+		assert.Equal(t, "dinâmico", findings[2].Text)
+		assert.Equal(t, 36, findings[2].Start)
+		assert.Equal(t, 44, findings[2].End)
+		assert.Equal(t, CategoryAgeBiased, findings[2].Category)
+	})
+
+	t.Run("should not flag group dynamics in the selection process", func(t *testing.T) {
+		assert.Empty(t, linter.Lint("Etapas: entrevista e dinâmica de grupo"))
+	})
+
+	t.Run("should ignore case, accents and punctuation", func(t *testing.T) {
+		findings := linter.Lint("Exigimos BOA APARÊNCIA e ser recém-formada")
+
+		require.Len(t, findings, 2)
+		assert.Equal(t, "BOA APARÊNCIA", findings[0].Text)
+		assert.Equal(t, "recém-formada", findings[1].Text)
+	})
+
+	t.Run("should match word prefixes", func(t *testing.T) {
+		findings := linter.Lint("Vendedora agressiva")
+
+		require.Len(t, findings, 1)
+		assert.Equal(t, "agressiva", findings[0].Text)
+	})
+
+	t.Run("should prefer the longest phrase", func(t *testing.T) {
+		findings := linter.Lint("Native English speakers only")
+
+		require.Len(t, findings, 1)
+		assert.Equal(t, "Native English speakers", findings[0].Text)
+	})
+
+	t.Run("should skip exceptions", func(t *testing.T) {
+		assert.Empty(t, linter.Lint("Programa Jovem Aprendiz 2025"))
+	})
+
+	t.Run("should not match inside other words", func(t *testing.T) {
+		assert.Empty(t, linter.Lint("Youngstown office, salesmanship training"))
+	})
+
+	t.Run("should restrict the word lists to the given languages", func(t *testing.T) {
+		assert.Empty(t, linter.Lint("young team", "pt"))
+		assert.Len(t, linter.Lint("young team", "en"), 1)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should reject unknown categories", func(t *testing.T) {
+		_, err := New(WordList{Language: "pt", Entries: []Entry{{Phrase: "x", Category: "rude"}}})
+
+		assert.ErrorContains(t, err, `unknown category "rude"`)
+	})
+
+	t.Run("should build a linter from custom lists", func(t *testing.T) {
+		linter, err := New(WordList{Language: "pt", Entries: []Entry{{Phrase: "super-herói", Category: CategoryGenderCoded}}})
+		require.NoError(t, err)
+
+		findings := linter.Lint("Buscamos um super herói")
+
+		require.Len(t, findings, 1)
+		assert.Equal(t, "super herói", findings[0].Text)
+		assert.Equal(t, categoryMessages[CategoryGenderCoded], findings[0].Message)
+	})
+}
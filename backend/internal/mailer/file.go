@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes each message to its own .eml file in Dir instead of
+// sending it, so email can be inspected during local development.
+type FileMailer struct {
+	Dir  string
+	from *mail.Address
+}
+
+func NewFileMailer(dir string, from *mail.Address) *FileMailer {
+	return &FileMailer{Dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix))
+	return os.WriteFile(filepath.Join(m.Dir, name), render(m.from, msg, now), 0o644)
+}
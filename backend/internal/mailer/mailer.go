@@ -0,0 +1,79 @@
+// Package mailer delivers plain-text email through interchangeable
+// backends so features can send mail without knowing how it leaves the
+// process.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"sort"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/config"
+)
+
+// Message is a plain-text email. Headers holds extra headers such as
+// List-Unsubscribe.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	Headers map[string]string
+}
+
+// Mailer sends messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns the mailer selected by cfg.Driver.
+func New(cfg config.MailConfig) (Mailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	switch cfg.Driver {
+	case "file":
+		return NewFileMailer(cfg.FileDir, from), nil
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, from), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// render formats msg as an RFC 5322 message in UTF-8.
+func render(from *mail.Address, msg Message, now time.Time) []byte {
+	var buf bytes.Buffer
+
+	headers := map[string]string{
+		"From":                      from.String(),
+		"To":                        msg.To,
+		"Subject":                   mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date":                      now.Format(time.RFC1123Z),
+		"MIME-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding": "8bit",
+	}
+	for name, value := range msg.Headers {
+		headers[name] = value
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, headers[name])
+	}
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,163 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("should encode non-ASCII subjects and include extra headers", func(t *testing.T) {
+		from := &mail.Address{Name: "Vagas", Address: "no-reply@example.com"}
+		msg := Message{
+			To:      "ana@example.com",
+			Subject: "Novas vagas em Niterói",
+			Body:    "Olá!",
+			Headers: map[string]string{"List-Unsubscribe": "<http://localhost/unsubscribe>"},
+		}
+
+		raw := string(render(from, msg, time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)))
+
+		parsed, err := mail.ReadMessage(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "ana@example.com", parsed.Header.Get("To"))
+		assert.Equal(t, "<http://localhost/unsubscribe>", parsed.Header.Get("List-Unsubscribe"))
+		assert.NotContains(t, parsed.Header.Get("Subject"), "ó")
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Novas vagas em Niterói", subject)
+		assert.True(t, strings.HasSuffix(raw, "\r\n\r\nOlá!"))
+	})
+}
+
+func TestFileMailer(t *testing.T) {
+	t.Run("should write each message to its own file", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "mail")
+		m, err := New(config.MailConfig{Driver: "file", From: "no-reply@example.com", FileDir: dir})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, m.Send(context.Background(), Message{To: "ana@example.com", Subject: "Olá", Body: "corpo"}))
+		}
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+
+		content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "From: <no-reply@example.com>")
+	})
+
+	t.Run("should reject an invalid sender", func(t *testing.T) {
+		_, err := New(config.MailConfig{Driver: "file", From: "not an address"})
+
+		assert.Error(t, err)
+	})
+}
+
+// fakeSMTPServer accepts one connection and answers every command with OK,
+// collecting the message data.
+func fakeSMTPServer(t *testing.T) (addr string, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					out <- data.String()
+					reply("250 queued")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "DATA"):
+				inData = true
+				reply("354 go ahead")
+			case strings.HasPrefix(cmd, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().String(), out
+}
+
+func TestSMTPMailer(t *testing.T) {
+	from := &mail.Address{Address: "no-reply@example.com"}
+
+	t.Run("should deliver the rendered message", func(t *testing.T) {
+		addr, received := fakeSMTPServer(t)
+		host, port, err := net.SplitHostPort(addr)
+		require.NoError(t, err)
+		m := NewSMTPMailer(host, port, "", "", from)
+
+		err = m.Send(context.Background(), Message{To: "ana@example.com", Subject: "Olá", Body: "corpo"})
+
+		require.NoError(t, err)
+		data := <-received
+		assert.Contains(t, data, "To: ana@example.com")
+		assert.Contains(t, data, "corpo")
+	})
+
+	t.Run("should give up when the context deadline passes", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			// Accept and stay silent, like a stalled server.
+			conn, err := ln.Accept()
+			if err == nil {
+				defer conn.Close()
+				time.Sleep(2 * time.Second)
+			}
+		}()
+		host, port, err := net.SplitHostPort(ln.Addr().String())
+		require.NoError(t, err)
+		m := NewSMTPMailer(host, port, "", "", from)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err = m.Send(ctx, Message{To: "ana@example.com", Subject: "Olá", Body: "corpo"})
+
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -47,3 +47,23 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware sets the user context when a valid access token is
+// sent and otherwise lets the request through anonymously, so public routes
+// can tailor their response to the caller.
+func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader(AuthorizationHeader)
+		if !strings.HasPrefix(authHeader, BearerPrefix) {
+			c.Next()
+			return
+		}
+
+		claims, err := jwt.ValidateToken(strings.TrimPrefix(authHeader, BearerPrefix), jwtSecret)
+		if err == nil && claims.Type == "access" {
+			c.Set(UserContextKey, claims)
+		}
+
+		c.Next()
+	}
+}
@@ -27,7 +27,7 @@ func TestAuthMiddleware(t *testing.T) {
 	}
 
 	t.Run("should allow access with valid access token", func(t *testing.T) {
-		user := testutil.ledufranco("test@example.com", "password", models.RoleAdmin)
+		user := testutil.CreateTestUser("test@example.com", "password", models.RoleAdmin)
 		token, _ := testutil.GenerateTestToken(user, testSecret, "access", 15*time.Minute)
 
 		router := setupRouter()
@@ -126,3 +126,60 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setupRouter := func() *gin.Engine {
+		r := gin.New()
+		r.Use(OptionalAuthMiddleware(testSecret))
+		r.GET("/public", func(c *gin.Context) {
+			_, authenticated := c.Get(UserContextKey)
+			c.JSON(http.StatusOK, gin.H{"authenticated": authenticated})
+		})
+		return r
+	}
+
+	t.Run("should allow anonymous access", func(t *testing.T) {
+		router := setupRouter()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/public", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"authenticated":false`)
+	})
+
+	t.Run("should set user context with valid access token", func(t *testing.T) {
+		user := testutil.CreateTestUser("candidate@example.com", "password", models.RoleCandidate)
+		token, _ := testutil.GenerateTestToken(user, testSecret, "access", 15*time.Minute)
+
+		router := setupRouter()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/public", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"authenticated":true`)
+	})
+
+	t.Run("should treat invalid or refresh tokens as anonymous", func(t *testing.T) {
+		user := testutil.CreateTestUser("candidate@example.com", "password", models.RoleCandidate)
+		refresh, _ := testutil.GenerateTestToken(user, testSecret, "refresh", 15*time.Minute)
+
+		for _, token := range []string{"invalid.token.here", refresh} {
+			router := setupRouter()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/public", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"authenticated":false`)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// PublicCORS lets any site read the public endpoints under the given path
+// prefixes, such as a careers page embedded in a company's own site, without
+// credentials. Every other request goes through app, the API's own policy.
+func PublicCORS(app gin.HandlerFunc, prefixes ...string) gin.HandlerFunc {
+	public := cors.New(cors.Config{
+		AllowAllOrigins: true,
+		AllowMethods:    []string{"GET", "OPTIONS"},
+		AllowHeaders:    []string{"Origin", "Accept-Language"},
+		ExposeHeaders:   []string{"Content-Length", "Content-Language"},
+		MaxAge:          12 * time.Hour,
+	})
+
+	return func(c *gin.Context) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				public(c)
+				return
+			}
+		}
+		app(c)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(PublicCORS(cors.New(cors.Config{
+		AllowOrigins:     []string{"http://localhost:3000"},
+		AllowMethods:     []string{"GET"},
+		AllowCredentials: true,
+	}), "/api/careers/"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/careers/:slug", ok)
+	router.GET("/api/jobs", ok)
+
+	request := func(path, origin string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Origin", origin)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should let any site read public routes", func(t *testing.T) {
+		w := request("/api/careers/acme", "https://acme.example.com")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("should keep the app policy on other routes", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request("/api/jobs", "https://acme.example.com").Code)
+
+		w := request("/api/jobs", "http://localhost:3000")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -17,38 +17,60 @@ const (
 )
 
 type Application struct {
-	ID          uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	JobID       uuid.UUID         `gorm:"type:uuid;not null" json:"job_id"`
-	CandidateID uuid.UUID         `gorm:"type:uuid;not null" json:"candidate_id"`
-	Status      ApplicationStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt    `gorm:"index" json:"-"`
+	ID                 uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID              uuid.UUID         `gorm:"type:uuid;not null" json:"job_id"`
+	CandidateID        uuid.UUID         `gorm:"type:uuid;not null" json:"candidate_id"`
+	Status             ApplicationStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	StatusMessage      string            `gorm:"type:text" json:"status_message,omitempty"`
+	JobRevisionID      *uuid.UUID        `gorm:"type:uuid" json:"job_revision_id,omitempty"`
+	KnockoutRuleID     *uuid.UUID        `gorm:"type:uuid" json:"knockout_rule_id,omitempty"`
+	KnockoutReason     string            `gorm:"type:text" json:"knockout_reason,omitempty"`
+	Flagged            bool              `gorm:"not null;default:false" json:"flagged"`
+	RejectionVisibleAt *time.Time        `json:"rejection_visible_at,omitempty"`
+	Source             ApplicationSource `gorm:"embedded;embeddedPrefix:source_" json:"source"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt    `gorm:"index" json:"-"`
 
-	
-	Job       Job  `gorm:"foreignKey:JobID" json:"job,omitempty"`
-	Candidate User `gorm:"foreignKey:CandidateID" json:"candidate,omitempty"`
+	Job       Job                 `gorm:"foreignKey:JobID" json:"job,omitempty"`
+	Candidate User                `gorm:"foreignKey:CandidateID" json:"candidate,omitempty"`
+	Answers   []ApplicationAnswer `gorm:"foreignKey:ApplicationID" json:"answers,omitempty"`
 }
 
 type ApplicationResponse struct {
-	ID          uuid.UUID         `json:"id"`
-	JobID       uuid.UUID         `json:"job_id"`
-	CandidateID uuid.UUID         `json:"candidate_id"`
-	Status      ApplicationStatus `json:"status"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	Job         *JobResponse      `json:"job,omitempty"`
-	Candidate   *UserResponse     `json:"candidate,omitempty"`
+	ID                 uuid.UUID                   `json:"id"`
+	JobID              uuid.UUID                   `json:"job_id"`
+	CandidateID        uuid.UUID                   `json:"candidate_id"`
+	Status             ApplicationStatus           `json:"status"`
+	StatusMessage      string                      `json:"status_message,omitempty"`
+	JobRevisionID      *uuid.UUID                  `json:"job_revision_id,omitempty"`
+	KnockoutRuleID     *uuid.UUID                  `json:"knockout_rule_id,omitempty"`
+	KnockoutReason     string                      `json:"knockout_reason,omitempty"`
+	Flagged            bool                        `json:"flagged"`
+	RejectionVisibleAt *time.Time                  `json:"rejection_visible_at,omitempty"`
+	Source             *ApplicationSource          `json:"source,omitempty"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	UpdatedAt          time.Time                   `json:"updated_at"`
+	Job                *JobResponse                `json:"job,omitempty"`
+	Candidate          *UserResponse               `json:"candidate,omitempty"`
+	Answers            []ApplicationAnswerResponse `json:"answers,omitempty"`
 }
 
 func (a *Application) ToResponse(includeJob, includeCandidate bool) ApplicationResponse {
 	resp := ApplicationResponse{
-		ID:          a.ID,
-		JobID:       a.JobID,
-		CandidateID: a.CandidateID,
-		Status:      a.Status,
-		CreatedAt:   a.CreatedAt,
-		UpdatedAt:   a.UpdatedAt,
+		ID:                 a.ID,
+		JobID:              a.JobID,
+		CandidateID:        a.CandidateID,
+		Status:             a.Status,
+		StatusMessage:      a.StatusMessage,
+		JobRevisionID:      a.JobRevisionID,
+		KnockoutRuleID:     a.KnockoutRuleID,
+		KnockoutReason:     a.KnockoutReason,
+		Flagged:            a.Flagged,
+		RejectionVisibleAt: a.RejectionVisibleAt,
+		Source:             &a.Source,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
 	}
 
 	if includeJob && a.Job.ID != uuid.Nil {
@@ -61,5 +83,31 @@ func (a *Application) ToResponse(includeJob, includeCandidate bool) ApplicationR
 		resp.Candidate = &candidateResp
 	}
 
+	if len(a.Answers) > 0 {
+		resp.Answers = make([]ApplicationAnswerResponse, len(a.Answers))
+		for i, answer := range a.Answers {
+			resp.Answers[i] = answer.ToResponse()
+		}
+	}
+
+	return resp
+}
+
+// ToCandidateResponse is the candidate's view of the application: screening
+// details and the source are hidden and a delayed knockout rejection reads as pending until
+// its notice time.
+func (a *Application) ToCandidateResponse(now time.Time) ApplicationResponse {
+	resp := a.ToResponse(true, false)
+	resp.KnockoutRuleID = nil
+	resp.Flagged = false
+	resp.RejectionVisibleAt = nil
+	resp.Source = nil
+
+	if a.Status == ApplicationStatusRejected && a.RejectionVisibleAt != nil && a.RejectionVisibleAt.After(now) {
+		resp.Status = ApplicationStatusPending
+		resp.StatusMessage = ""
+		resp.KnockoutReason = ""
+	}
+
 	return resp
 }
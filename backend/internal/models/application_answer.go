@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationAnswer is a candidate's answer to a screening question. The
+// prompt and type are copied so the answer stays readable if the job's
+// questions are later edited.
+type ApplicationAnswer struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ApplicationID uuid.UUID    `gorm:"type:uuid;not null;index" json:"application_id"`
+	QuestionID    uuid.UUID    `gorm:"type:uuid;not null" json:"question_id"`
+	Prompt        string       `gorm:"type:text;not null" json:"prompt"`
+	Type          QuestionType `gorm:"type:varchar(20);not null" json:"type"`
+	Value         JSONValue    `gorm:"type:jsonb" json:"value"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type ApplicationAnswerResponse struct {
+	QuestionID uuid.UUID    `json:"question_id"`
+	Prompt     string       `json:"prompt"`
+	Type       QuestionType `json:"type"`
+	Value      JSONValue    `json:"value" swaggertype:"object"`
+}
+
+func (a *ApplicationAnswer) ToResponse() ApplicationAnswerResponse {
+	return ApplicationAnswerResponse{
+		QuestionID: a.QuestionID,
+		Prompt:     a.Prompt,
+		Type:       a.Type,
+		Value:      a.Value,
+	}
+}
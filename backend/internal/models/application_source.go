@@ -0,0 +1,91 @@
+package models
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Channels that are not a campaign tag or referring site.
+const (
+	SourceChannelDirect   = "direct"
+	SourceChannelReferral = "referral"
+)
+
+// ApplicationSource is where a candidate came from before applying: the
+// explicit channel code of the link (?ref=), its UTM parameters, the site
+// that linked to the job and the employee who referred the candidate.
+// Channel summarizes them for reports.
+type ApplicationSource struct {
+	Channel      string     `gorm:"type:varchar(100);not null;default:'direct';index" json:"channel"`
+	UTMSource    string     `gorm:"type:varchar(255)" json:"utm_source,omitempty"`
+	UTMMedium    string     `gorm:"type:varchar(255)" json:"utm_medium,omitempty"`
+	UTMCampaign  string     `gorm:"type:varchar(255)" json:"utm_campaign,omitempty"`
+	Referrer     string     `gorm:"type:varchar(255)" json:"referrer,omitempty"`
+	ReferredByID *uuid.UUID `gorm:"type:uuid;index" json:"referred_by_id,omitempty"`
+}
+
+// IsDirect reports whether the source carries nothing about where the
+// candidate came from.
+func (s ApplicationSource) IsDirect() bool {
+	return s.UTMSource == "" && s.UTMMedium == "" && s.UTMCampaign == "" &&
+		s.Referrer == "" && s.ReferredByID == nil &&
+		(s.Channel == "" || s.Channel == SourceChannelDirect)
+}
+
+// ResolveChannel sets Channel from the most specific information available:
+// an employee referral, then the explicit channel code, the UTM source and
+// finally the referring site.
+func (s *ApplicationSource) ResolveChannel() {
+	switch {
+	case s.ReferredByID != nil:
+		s.Channel = SourceChannelReferral
+	case s.Channel != "" && s.Channel != SourceChannelDirect && s.Channel != SourceChannelReferral:
+		// An explicit channel code wins over the UTM parameters.
+	case s.UTMSource != "":
+		s.Channel = s.UTMSource
+	case s.Referrer != "":
+		s.Channel = s.Referrer
+	default:
+		s.Channel = SourceChannelDirect
+	}
+}
+
+type SourceCount struct {
+	Channel      string  `json:"channel"`
+	Applications int64   `json:"applications"`
+	Approved     int64   `json:"approved"`
+	ApprovalRate float64 `json:"approval_rate"`
+}
+
+// SourceReport counts applications and approvals per channel over
+// [From, To], for one job or, when JobID is nil, for every job.
+type SourceReport struct {
+	JobID        *uuid.UUID    `json:"job_id,omitempty"`
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	Applications int64         `json:"applications"`
+	Approved     int64         `json:"approved"`
+	Sources      []SourceCount `json:"sources"`
+}
+
+// NewSourceReport totals counts and fills in each channel's approval rate.
+func NewSourceReport(jobID *uuid.UUID, from, to string, counts []SourceCount) SourceReport {
+	report := SourceReport{JobID: jobID, From: from, To: to, Sources: []SourceCount{}}
+	for _, count := range counts {
+		count.ApprovalRate = approvalRate(count.Approved, count.Applications)
+		report.Applications += count.Applications
+		report.Approved += count.Approved
+		report.Sources = append(report.Sources, count)
+	}
+	return report
+}
+
+// approvalRate is the share of applications approved, rounded to four
+// decimals like Conversion.
+func approvalRate(approved, applications int64) float64 {
+	if applications == 0 {
+		return 0
+	}
+	return math.Round(float64(approved)/float64(applications)*10000) / 10000
+}
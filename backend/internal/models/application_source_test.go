@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSourceReport(t *testing.T) {
+	t.Run("should total channels and compute approval rates", func(t *testing.T) {
+		report := NewSourceReport(nil, "2024-03-01", "2024-03-31", []SourceCount{
+			{Channel: "linkedin", Applications: 3, Approved: 1},
+			{Channel: SourceChannelDirect, Applications: 1},
+		})
+
+		assert.Equal(t, int64(4), report.Applications)
+		assert.Equal(t, int64(1), report.Approved)
+		assert.Equal(t, 0.3333, report.Sources[0].ApprovalRate)
+		assert.Equal(t, 0.0, report.Sources[1].ApprovalRate)
+	})
+
+	t.Run("should list no sources as an empty array", func(t *testing.T) {
+		report := NewSourceReport(nil, "2024-03-01", "2024-03-31", nil)
+
+		assert.NotNil(t, report.Sources)
+		assert.Empty(t, report.Sources)
+	})
+}
+
+func TestApplication_ToCandidateResponse_HidesSource(t *testing.T) {
+	t.Run("should not show candidates where they came from", func(t *testing.T) {
+		app := Application{Source: ApplicationSource{Channel: "linkedin"}}
+
+		assert.NotNil(t, app.ToResponse(false, false).Source)
+		assert.Nil(t, app.ToCandidateResponse(app.CreatedAt).Source)
+	})
+}
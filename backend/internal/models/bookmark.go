@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark is a job a candidate shortlisted. ClosingNoticeSentAt records
+// when the candidate was warned that the job is about to close, so the
+// warning goes out once.
+type Bookmark struct {
+	CandidateID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"candidate_id"`
+	JobID               uuid.UUID  `gorm:"type:uuid;primaryKey;index" json:"job_id"`
+	ClosingNoticeSentAt *time.Time `json:"closing_notice_sent_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+
+	Job       Job  `gorm:"foreignKey:JobID" json:"job,omitempty"`
+	Candidate User `gorm:"foreignKey:CandidateID" json:"-"`
+}
+
+type BookmarkResponse struct {
+	JobID       uuid.UUID    `json:"job_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	ClosingSoon bool         `json:"closing_soon"`
+	Job         *JobResponse `json:"job,omitempty"`
+}
+
+// ToResponse reports the job as closing soon when it is open and expires
+// within window of now.
+func (b *Bookmark) ToResponse(now time.Time, window time.Duration) BookmarkResponse {
+	resp := BookmarkResponse{
+		JobID:       b.JobID,
+		CreatedAt:   b.CreatedAt,
+		ClosingSoon: b.Job.ClosesWithin(now, window),
+	}
+	if b.Job.ID != uuid.Nil {
+		jobResp := b.Job.ToResponse(false)
+		bookmarked := true
+		jobResp.Bookmarked = &bookmarked
+		resp.Job = &jobResp
+	}
+	return resp
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob_ClosesWithin(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	t.Run("should report open jobs expiring inside the window", func(t *testing.T) {
+		job := Job{Status: JobStatusOpen, ExpiresAt: at(24 * time.Hour)}
+
+		assert.True(t, job.ClosesWithin(now, 48*time.Hour))
+		assert.False(t, job.ClosesWithin(now, 12*time.Hour))
+	})
+
+	t.Run("should ignore jobs without expiry, already expired or not open", func(t *testing.T) {
+		assert.False(t, (&Job{Status: JobStatusOpen}).ClosesWithin(now, 48*time.Hour))
+		assert.False(t, (&Job{Status: JobStatusOpen, ExpiresAt: at(-time.Hour)}).ClosesWithin(now, 48*time.Hour))
+		assert.False(t, (&Job{Status: JobStatusClosed, ExpiresAt: at(time.Hour)}).ClosesWithin(now, 48*time.Hour))
+	})
+}
+
+func TestBookmark_ToResponse(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(6 * time.Hour)
+
+	t.Run("should flag the job as bookmarked and closing soon", func(t *testing.T) {
+		bookmark := Bookmark{
+			JobID: uuid.New(),
+			Job:   Job{ID: uuid.New(), Status: JobStatusOpen, ExpiresAt: &expiresAt},
+		}
+
+		resp := bookmark.ToResponse(now, 48*time.Hour)
+
+		assert.True(t, resp.ClosingSoon)
+		if assert.NotNil(t, resp.Job) && assert.NotNil(t, resp.Job.Bookmarked) {
+			assert.True(t, *resp.Job.Bookmarked)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+)
+
+// EmployerProfile is the branding a recruiter attaches to their account. It
+// names the public careers page at /api/careers/{slug}, which lists the
+// recruiter's open jobs.
+type EmployerProfile struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Slug      string    `gorm:"type:varchar(120);not null;uniqueIndex" json:"slug"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	LogoURL   string    `gorm:"type:varchar(2048)" json:"logo_url,omitempty"`
+	About     string    `gorm:"type:text" json:"about"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type EmployerProfileResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	LogoURL   string    `json:"logo_url,omitempty"`
+	About     string    `json:"about"`
+	AboutHTML string    `json:"about_html"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToResponse renders the Markdown about text to sanitized HTML, like job
+// descriptions.
+func (p *EmployerProfile) ToResponse() EmployerProfileResponse {
+	return EmployerProfileResponse{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Slug:      p.Slug,
+		Name:      p.Name,
+		LogoURL:   p.LogoURL,
+		About:     p.About,
+		AboutHTML: markdown.ToHTML(p.About),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
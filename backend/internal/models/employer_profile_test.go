@@ -0,0 +1,20 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmployerProfile_ToResponse(t *testing.T) {
+	t.Run("should render the about text as sanitized HTML", func(t *testing.T) {
+		profile := EmployerProfile{Name: "Acme", Slug: "acme", About: "Somos a **Acme**<script>x</script>"}
+
+		resp := profile.ToResponse()
+
+		assert.Equal(t, "acme", resp.Slug)
+		assert.Equal(t, profile.About, resp.About)
+		assert.Contains(t, resp.AboutHTML, "<strong>Acme</strong>")
+		assert.NotContains(t, resp.AboutHTML, "<script>")
+	})
+}
@@ -1,69 +1,449 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
 	"gorm.io/gorm"
 )
 
 type JobType string
 type JobStatus string
+type SalaryPeriod string
+type ContractType string
 
 const (
 	JobTypeRemote JobType = "remote"
 	JobTypeOnsite JobType = "onsite"
 	JobTypeHybrid JobType = "hybrid"
 
-	JobStatusOpen     JobStatus = "open"
-	JobStatusClosed   JobStatus = "closed"
-	JobStatusArchived JobStatus = "archived"
+	JobStatusDraft           JobStatus = "draft"
+	JobStatusPendingApproval JobStatus = "pending_approval"
+	JobStatusScheduled       JobStatus = "scheduled"
+	JobStatusOpen            JobStatus = "open"
+	JobStatusClosed          JobStatus = "closed"
+	JobStatusArchived        JobStatus = "archived"
+
+	SalaryPeriodHour  SalaryPeriod = "hour"
+	SalaryPeriodMonth SalaryPeriod = "month"
+	SalaryPeriodYear  SalaryPeriod = "year"
+
+	ContractTypeFullTime   ContractType = "full_time"
+	ContractTypePartTime   ContractType = "part_time"
+	ContractTypeContractor ContractType = "contractor"
+	ContractTypeTemporary  ContractType = "temporary"
+	ContractTypeInternship ContractType = "internship"
+
+	DefaultSalaryCurrency = "BRL"
+
+	// HoursPerYear assumes a 40h week over 52 weeks.
+	HoursPerYear = 2080
+)
+
+var (
+	ErrInvalidSalaryRange  = errors.New("salary_min must be less than or equal to salary_max")
+	ErrNegativeSalary      = errors.New("salary values must not be negative")
+	ErrInvalidSalaryPeriod = errors.New("salary_period must be one of hour, month, year")
+	ErrInvalidSchedule     = errors.New("expires_at must be after publish_at")
+	ErrInvalidDeadline     = errors.New("application_deadline must be after publish_at and not after expires_at")
+	ErrMissingJobFields    = errors.New("title, description, location and type are required")
+	ErrInvalidOpenings     = errors.New("openings must be at least 1")
 )
 
+// jobStatusTransitions is the job state machine: the statuses each status may
+// move to. Publication always goes through pending_approval.
+var jobStatusTransitions = map[JobStatus][]JobStatus{
+	JobStatusDraft:           {JobStatusPendingApproval, JobStatusArchived},
+	JobStatusPendingApproval: {JobStatusDraft, JobStatusScheduled, JobStatusOpen},
+	JobStatusScheduled:       {JobStatusOpen, JobStatusClosed},
+	JobStatusOpen:            {JobStatusClosed, JobStatusArchived},
+	JobStatusClosed:          {JobStatusOpen, JobStatusArchived},
+	JobStatusArchived:        {},
+}
+
+// PublishedJobStatuses are the statuses visible outside the hiring team.
+var PublishedJobStatuses = []JobStatus{JobStatusOpen, JobStatusClosed, JobStatusArchived}
+
+// CanTransitionTo reports whether the state machine allows moving from s to
+// next.
+func (s JobStatus) CanTransitionTo(next JobStatus) bool {
+	for _, allowed := range jobStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublished reports whether jobs in this status have been through approval
+// and may be shown publicly.
+func (s JobStatus) IsPublished() bool {
+	for _, published := range PublishedJobStatuses {
+		if s == published {
+			return true
+		}
+	}
+	return false
+}
+
 type Job struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	RecruiterID uuid.UUID      `gorm:"type:uuid;not null" json:"recruiter_id"`
-	Title       string         `gorm:"not null" json:"title"`
-	Description string         `gorm:"type:text;not null" json:"description"`
-	Salary      *float64       `json:"salary,omitempty"`
-	Location    string         `json:"location"`
-	Type        JobType        `gorm:"type:varchar(20);not null" json:"type"`
-	Status      JobStatus      `gorm:"type:varchar(20);default:'open'" json:"status"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-
-	
+	ID                  uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RecruiterID         uuid.UUID      `gorm:"type:uuid;not null" json:"recruiter_id"`
+	Slug                string         `gorm:"type:varchar(120);uniqueIndex" json:"slug"`
+	Title               string         `gorm:"not null" json:"title"`
+	Description         string         `gorm:"type:text;not null" json:"description"`
+	DescriptionText     string         `gorm:"type:text" json:"-"`
+	SalaryMin           *float64       `json:"salary_min,omitempty"`
+	SalaryMax           *float64       `json:"salary_max,omitempty"`
+	SalaryCurrency      string         `gorm:"type:varchar(3);not null;default:'BRL'" json:"salary_currency"`
+	SalaryPeriod        SalaryPeriod   `gorm:"type:varchar(10);not null;default:'month'" json:"salary_period"`
+	SalaryNegotiable    bool           `gorm:"not null;default:false" json:"salary_negotiable"`
+	SalaryAnnualMin     *float64       `gorm:"index" json:"-"`
+	SalaryAnnualMax     *float64       `gorm:"index" json:"-"`
+	Location            string         `json:"location"`
+	City                string         `gorm:"type:varchar(100)" json:"city,omitempty"`
+	State               string         `gorm:"type:varchar(2)" json:"state,omitempty"`
+	Country             string         `gorm:"type:varchar(2)" json:"country,omitempty"`
+	Latitude            *float64       `gorm:"index:idx_jobs_coordinates" json:"latitude,omitempty"`
+	Longitude           *float64       `gorm:"index:idx_jobs_coordinates" json:"longitude,omitempty"`
+	Type                JobType        `gorm:"type:varchar(20);not null" json:"type"`
+	ContractType        ContractType   `gorm:"type:varchar(20)" json:"contract_type,omitempty"`
+	Locale              Locale         `gorm:"type:varchar(10);not null;default:'pt-BR'" json:"locale"`
+	Status              JobStatus      `gorm:"type:varchar(20);default:'draft'" json:"status"`
+	Openings            int            `gorm:"not null;default:1" json:"openings"`
+	Filled              int            `gorm:"not null;default:0" json:"filled"`
+	AutoRejectOnFill    bool           `gorm:"not null;default:false" json:"auto_reject_on_fill"`
+	FillMessage         string         `gorm:"type:text" json:"fill_message,omitempty"`
+	PublishAt           *time.Time     `gorm:"index" json:"publish_at,omitempty"`
+	ExpiresAt           *time.Time     `gorm:"index" json:"expires_at,omitempty"`
+	ApplicationDeadline *time.Time     `gorm:"index" json:"application_deadline,omitempty"`
+	PublishedAt         *time.Time     `json:"published_at,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
+
 	Recruiter    User          `gorm:"foreignKey:RecruiterID" json:"recruiter,omitempty"`
 	Applications []Application `gorm:"foreignKey:JobID" json:"applications,omitempty"`
+	Questions    []JobQuestion `gorm:"foreignKey:JobID" json:"questions,omitempty"`
+	Skills       []JobSkill    `gorm:"foreignKey:JobID" json:"skills,omitempty"`
 }
 
 type JobResponse struct {
-	ID          uuid.UUID    `json:"id"`
-	RecruiterID uuid.UUID    `json:"recruiter_id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Salary      *float64     `json:"salary,omitempty"`
-	Location    string       `json:"location"`
-	Type        JobType      `json:"type"`
-	Status      JobStatus    `json:"status"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	Recruiter   *UserResponse `json:"recruiter,omitempty"`
+	ID                  uuid.UUID             `json:"id"`
+	RecruiterID         uuid.UUID             `json:"recruiter_id"`
+	Slug                string                `json:"slug"`
+	Title               string                `json:"title"`
+	Description         string                `json:"description"`
+	DescriptionHTML     string                `json:"description_html"`
+	SalaryMin           *float64              `json:"salary_min,omitempty"`
+	SalaryMax           *float64              `json:"salary_max,omitempty"`
+	SalaryCurrency      string                `json:"salary_currency"`
+	SalaryPeriod        SalaryPeriod          `json:"salary_period"`
+	SalaryNegotiable    bool                  `json:"salary_negotiable"`
+	Location            string                `json:"location"`
+	City                string                `json:"city,omitempty"`
+	State               string                `json:"state,omitempty"`
+	Country             string                `json:"country,omitempty"`
+	Latitude            *float64              `json:"latitude,omitempty"`
+	Longitude           *float64              `json:"longitude,omitempty"`
+	DistanceKm          *float64              `json:"distance_km,omitempty"`
+	Similarity          *float64              `json:"similarity,omitempty"`
+	Type                JobType               `json:"type"`
+	ContractType        ContractType          `json:"contract_type,omitempty"`
+	Locale              Locale                `json:"locale"`
+	Status              JobStatus             `json:"status"`
+	Openings            int                   `json:"openings"`
+	Filled              int                   `json:"filled"`
+	Remaining           int                   `json:"remaining"`
+	AutoRejectOnFill    bool                  `json:"auto_reject_on_fill"`
+	FillMessage         string                `json:"fill_message,omitempty"`
+	PublishAt           *time.Time            `json:"publish_at,omitempty"`
+	ExpiresAt           *time.Time            `json:"expires_at,omitempty"`
+	ApplicationDeadline *time.Time            `json:"application_deadline,omitempty"`
+	PublishedAt         *time.Time            `json:"published_at,omitempty"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	Recruiter           *UserResponse         `json:"recruiter,omitempty"`
+	Questions           []JobQuestionResponse `json:"questions,omitempty"`
+	Skills              []JobSkillResponse    `json:"skills,omitempty"`
+	// Bookmarked is only set for authenticated candidates.
+	Bookmarked *bool `json:"bookmarked,omitempty"`
+
+	// Deprecated: use SalaryMin/SalaryMax. Kept while clients migrate.
+	Salary *float64 `json:"salary,omitempty"`
+}
+
+// ValidSalaryPeriod reports whether p is one of the supported pay periods.
+func ValidSalaryPeriod(p SalaryPeriod) bool {
+	switch p {
+	case SalaryPeriodHour, SalaryPeriodMonth, SalaryPeriodYear:
+		return true
+	}
+	return false
+}
+
+// AnnualizeSalary converts an amount paid per period into a yearly amount.
+func AnnualizeSalary(amount float64, period SalaryPeriod) float64 {
+	switch period {
+	case SalaryPeriodHour:
+		return amount * HoursPerYear
+	case SalaryPeriodYear:
+		return amount
+	default:
+		return amount * 12
+	}
+}
+
+// ValidateSalary checks the salary range fields for consistency.
+func (j *Job) ValidateSalary() error {
+	if j.SalaryPeriod != "" && !ValidSalaryPeriod(j.SalaryPeriod) {
+		return ErrInvalidSalaryPeriod
+	}
+	if (j.SalaryMin != nil && *j.SalaryMin < 0) || (j.SalaryMax != nil && *j.SalaryMax < 0) {
+		return ErrNegativeSalary
+	}
+	if j.SalaryMin != nil && j.SalaryMax != nil && *j.SalaryMin > *j.SalaryMax {
+		return ErrInvalidSalaryRange
+	}
+	return nil
+}
+
+// ValidateRequired checks that the fields every posting needs are filled in.
+func (j *Job) ValidateRequired() error {
+	if j.Title == "" || j.Description == "" || j.Location == "" || j.Type == "" {
+		return ErrMissingJobFields
+	}
+	return nil
+}
+
+// CloneAsDraft copies the posting content of the job into a new, unsaved
+// draft owned by recruiterID.
+func (j *Job) CloneAsDraft(recruiterID uuid.UUID) *Job {
+	return &Job{
+		RecruiterID:      recruiterID,
+		Title:            j.Title,
+		Description:      j.Description,
+		SalaryMin:        j.SalaryMin,
+		SalaryMax:        j.SalaryMax,
+		SalaryCurrency:   j.SalaryCurrency,
+		SalaryPeriod:     j.SalaryPeriod,
+		SalaryNegotiable: j.SalaryNegotiable,
+		Location:         j.Location,
+		Type:             j.Type,
+		ContractType:     j.ContractType,
+		Locale:           j.Locale,
+		Status:           JobStatusDraft,
+		Openings:         j.Openings,
+		AutoRejectOnFill: j.AutoRejectOnFill,
+		FillMessage:      j.FillMessage,
+	}
+}
+
+// Remaining is the number of openings not yet filled.
+func (j *Job) Remaining() int {
+	if j.Filled >= j.Openings {
+		return 0
+	}
+	return j.Openings - j.Filled
+}
+
+// IsFilled reports whether every opening has an approved application.
+func (j *Job) IsFilled() bool {
+	return j.Openings > 0 && j.Filled >= j.Openings
+}
+
+// ApplicationsCloseAt is when the job stops taking applications: the
+// earlier of its application deadline and expiry, or nil when it has
+// neither.
+func (j *Job) ApplicationsCloseAt() *time.Time {
+	if j.ApplicationDeadline == nil {
+		return j.ExpiresAt
+	}
+	if j.ExpiresAt != nil && j.ExpiresAt.Before(*j.ApplicationDeadline) {
+		return j.ExpiresAt
+	}
+	return j.ApplicationDeadline
+}
+
+// ClosesWithin reports whether the job is open and stops taking
+// applications within window of now.
+func (j *Job) ClosesWithin(now time.Time, window time.Duration) bool {
+	closeAt := j.ApplicationsCloseAt()
+	if j.Status != JobStatusOpen || closeAt == nil || !closeAt.After(now) {
+		return false
+	}
+	return !closeAt.After(now.Add(window))
+}
+
+// DeadlinePassed reports whether the application deadline is over. The
+// deadline only stops applications: the job stays listed until it expires or
+// is closed.
+func (j *Job) DeadlinePassed(now time.Time) bool {
+	return j.ApplicationDeadline != nil && !j.ApplicationDeadline.After(now)
+}
+
+// ValidateSchedule checks that the publication window is consistent and
+// that the application deadline falls within it.
+func (j *Job) ValidateSchedule() error {
+	if j.PublishAt != nil && j.ExpiresAt != nil && !j.ExpiresAt.After(*j.PublishAt) {
+		return ErrInvalidSchedule
+	}
+	if j.ApplicationDeadline != nil {
+		if j.PublishAt != nil && !j.ApplicationDeadline.After(*j.PublishAt) {
+			return ErrInvalidDeadline
+		}
+		if j.ExpiresAt != nil && j.ApplicationDeadline.After(*j.ExpiresAt) {
+			return ErrInvalidDeadline
+		}
+	}
+	return nil
+}
+
+// ApplySchedule moves a job between scheduled, open and closed according to
+// PublishAt and ExpiresAt as of now. Jobs in any other status are left alone.
+func (j *Job) ApplySchedule(now time.Time) {
+	switch j.Status {
+	case JobStatusScheduled, JobStatusOpen:
+		if j.PublishAt != nil && j.PublishAt.After(now) {
+			j.Status = JobStatusScheduled
+			return
+		}
+		j.Status = JobStatusOpen
+		if j.ExpiresAt != nil && !j.ExpiresAt.After(now) {
+			j.Status = JobStatusClosed
+		}
+	}
+}
+
+// IsPubliclyVisible reports whether the job may be shown to the public.
+func (j *Job) IsPubliclyVisible(now time.Time) bool {
+	if !j.Status.IsPublished() {
+		return false
+	}
+	return j.PublishAt == nil || !j.PublishAt.After(now)
+}
+
+// AcceptsApplications reports whether candidates can currently apply.
+func (j *Job) AcceptsApplications(now time.Time) bool {
+	if j.Status != JobStatusOpen || !j.IsPubliclyVisible(now) || j.DeadlinePassed(now) {
+		return false
+	}
+	return j.ExpiresAt == nil || j.ExpiresAt.After(now)
+}
+
+// ResolveLocation fills the structured location from the free-text Location
+// using the bundled gazetteer, clearing it when the location is unknown.
+func (j *Job) ResolveLocation() {
+	place, ok := geo.Lookup(j.Location)
+	if !ok {
+		j.City, j.State, j.Country = "", "", ""
+		j.Latitude, j.Longitude = nil, nil
+		return
+	}
+	j.City, j.State, j.Country = place.City, place.State, place.Country
+	j.Latitude, j.Longitude = &place.Latitude, &place.Longitude
+}
+
+// DistanceFrom returns the distance in km between the job and place, or nil
+// for remote jobs and jobs without coordinates.
+func (j *Job) DistanceFrom(place geo.Place) *float64 {
+	if j.Type == JobTypeRemote || j.Latitude == nil || j.Longitude == nil {
+		return nil
+	}
+	d := geo.DistanceKm(place.Latitude, place.Longitude, *j.Latitude, *j.Longitude)
+	return &d
+}
+
+// BeforeSave fills salary defaults, keeps the annualized columns used by the
+// salary range filters in sync with the posted range, resolves the
+// structured location, keeps the plain-text description used by search in
+// sync and stamps PublishedAt the first time a job goes live.
+func (j *Job) BeforeSave(tx *gorm.DB) error {
+	if j.SalaryCurrency == "" {
+		j.SalaryCurrency = DefaultSalaryCurrency
+	}
+	if j.SalaryPeriod == "" {
+		j.SalaryPeriod = SalaryPeriodMonth
+	}
+	if j.Openings == 0 {
+		j.Openings = 1
+	}
+	if j.Locale == "" {
+		j.Locale = DefaultLocale
+	}
+	if j.Openings < 0 {
+		return ErrInvalidOpenings
+	}
+	if err := j.ValidateSalary(); err != nil {
+		return err
+	}
+	j.ResolveLocation()
+	j.DescriptionText = markdown.ToText(j.Description)
+	if j.Status == JobStatusOpen && j.PublishedAt == nil {
+		now := time.Now()
+		j.PublishedAt = &now
+	}
+
+	j.SalaryAnnualMin, j.SalaryAnnualMax = nil, nil
+	if j.SalaryMin != nil {
+		v := AnnualizeSalary(*j.SalaryMin, j.SalaryPeriod)
+		j.SalaryAnnualMin = &v
+	}
+	if j.SalaryMax != nil {
+		v := AnnualizeSalary(*j.SalaryMax, j.SalaryPeriod)
+		j.SalaryAnnualMax = &v
+	}
+	if j.SalaryAnnualMin == nil {
+		j.SalaryAnnualMin = j.SalaryAnnualMax
+	}
+	if j.SalaryAnnualMax == nil {
+		j.SalaryAnnualMax = j.SalaryAnnualMin
+	}
+	return nil
 }
 
 func (j *Job) ToResponse(includeRecruiter bool) JobResponse {
 	resp := JobResponse{
-		ID:          j.ID,
-		RecruiterID: j.RecruiterID,
-		Title:       j.Title,
-		Description: j.Description,
-		Salary:      j.Salary,
-		Location:    j.Location,
-		Type:        j.Type,
-		Status:      j.Status,
-		CreatedAt:   j.CreatedAt,
-		UpdatedAt:   j.UpdatedAt,
+		ID:                  j.ID,
+		RecruiterID:         j.RecruiterID,
+		Slug:                j.Slug,
+		Title:               j.Title,
+		Description:         j.Description,
+		DescriptionHTML:     markdown.ToHTML(j.Description),
+		SalaryMin:           j.SalaryMin,
+		SalaryMax:           j.SalaryMax,
+		SalaryCurrency:      j.SalaryCurrency,
+		SalaryPeriod:        j.SalaryPeriod,
+		SalaryNegotiable:    j.SalaryNegotiable,
+		Location:            j.Location,
+		City:                j.City,
+		State:               j.State,
+		Country:             j.Country,
+		Latitude:            j.Latitude,
+		Longitude:           j.Longitude,
+		Type:                j.Type,
+		ContractType:        j.ContractType,
+		Locale:              j.ContentLocale(),
+		Status:              j.Status,
+		Openings:            j.Openings,
+		Filled:              j.Filled,
+		Remaining:           j.Remaining(),
+		AutoRejectOnFill:    j.AutoRejectOnFill,
+		FillMessage:         j.FillMessage,
+		PublishAt:           j.PublishAt,
+		ExpiresAt:           j.ExpiresAt,
+		ApplicationDeadline: j.ApplicationDeadline,
+		PublishedAt:         j.PublishedAt,
+		CreatedAt:           j.CreatedAt,
+		UpdatedAt:           j.UpdatedAt,
+	}
+
+	resp.Salary = j.SalaryMin
+	if resp.Salary == nil {
+		resp.Salary = j.SalaryMax
 	}
 
 	if includeRecruiter && j.Recruiter.ID != uuid.Nil {
@@ -71,5 +451,19 @@ func (j *Job) ToResponse(includeRecruiter bool) JobResponse {
 		resp.Recruiter = &userResp
 	}
 
+	if len(j.Questions) > 0 {
+		resp.Questions = make([]JobQuestionResponse, len(j.Questions))
+		for i, question := range j.Questions {
+			resp.Questions[i] = question.ToResponse()
+		}
+	}
+
+	if len(j.Skills) > 0 {
+		resp.Skills = make([]JobSkillResponse, len(j.Skills))
+		for i, skill := range j.Skills {
+			resp.Skills[i] = skill.ToResponse()
+		}
+	}
+
 	return resp
 }
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionType string
+
+const (
+	QuestionTypeYesNo        QuestionType = "yes_no"
+	QuestionTypeSingleChoice QuestionType = "single_choice"
+	QuestionTypeMultiChoice  QuestionType = "multi_choice"
+	QuestionTypeNumber       QuestionType = "number"
+	QuestionTypeText         QuestionType = "text"
+
+	MaxTextAnswerLength = 5000
+)
+
+var ErrAnswerRequired = errors.New("an answer is required")
+
+// JobQuestion is a screening question candidates answer when applying.
+type JobQuestion struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"job_id"`
+	Position  int            `gorm:"not null" json:"position"`
+	Prompt    string         `gorm:"type:text;not null" json:"prompt"`
+	Type      QuestionType   `gorm:"type:varchar(20);not null" json:"type"`
+	Options   StringList     `gorm:"type:jsonb;not null" json:"options,omitempty"`
+	Required  bool           `gorm:"not null;default:false" json:"required"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type JobQuestionResponse struct {
+	ID       uuid.UUID    `json:"id"`
+	Position int          `json:"position"`
+	Prompt   string       `json:"prompt"`
+	Type     QuestionType `json:"type"`
+	Options  []string     `json:"options,omitempty"`
+	Required bool         `json:"required"`
+}
+
+func (q *JobQuestion) ToResponse() JobQuestionResponse {
+	return JobQuestionResponse{
+		ID:       q.ID,
+		Position: q.Position,
+		Prompt:   q.Prompt,
+		Type:     q.Type,
+		Options:  q.Options,
+		Required: q.Required,
+	}
+}
+
+// Validate checks that the question definition is usable.
+func (q *JobQuestion) Validate() error {
+	if strings.TrimSpace(q.Prompt) == "" {
+		return errors.New("prompt is required")
+	}
+
+	switch q.Type {
+	case QuestionTypeSingleChoice, QuestionTypeMultiChoice:
+		if len(q.Options) < 2 {
+			return errors.New("choice questions need at least two options")
+		}
+		seen := make(map[string]bool, len(q.Options))
+		for _, option := range q.Options {
+			if strings.TrimSpace(option) == "" {
+				return errors.New("options must not be empty")
+			}
+			if seen[option] {
+				return fmt.Errorf("duplicate option %q", option)
+			}
+			seen[option] = true
+		}
+	case QuestionTypeYesNo, QuestionTypeNumber, QuestionTypeText:
+		if len(q.Options) > 0 {
+			return fmt.Errorf("%s questions do not take options", q.Type)
+		}
+	default:
+		return fmt.Errorf("unknown question type %q", q.Type)
+	}
+
+	return nil
+}
+
+// ValidateAnswer checks a raw JSON answer against the question type. A
+// missing or null answer is only accepted for optional questions.
+func (q *JobQuestion) ValidateAnswer(raw json.RawMessage) error {
+	if IsEmptyAnswer(raw) {
+		if q.Required {
+			return ErrAnswerRequired
+		}
+		return nil
+	}
+
+	switch q.Type {
+	case QuestionTypeYesNo:
+		var answer bool
+		if err := json.Unmarshal(raw, &answer); err != nil {
+			return errors.New("answer must be true or false")
+		}
+	case QuestionTypeNumber:
+		var answer float64
+		if err := json.Unmarshal(raw, &answer); err != nil {
+			return errors.New("answer must be a number")
+		}
+	case QuestionTypeText:
+		var answer string
+		if err := json.Unmarshal(raw, &answer); err != nil {
+			return errors.New("answer must be text")
+		}
+		if q.Required && strings.TrimSpace(answer) == "" {
+			return ErrAnswerRequired
+		}
+		if len(answer) > MaxTextAnswerLength {
+			return fmt.Errorf("answer must be at most %d characters", MaxTextAnswerLength)
+		}
+	case QuestionTypeSingleChoice:
+		var answer string
+		if err := json.Unmarshal(raw, &answer); err != nil {
+			return errors.New("answer must be one of the options")
+		}
+		if !q.hasOption(answer) {
+			return fmt.Errorf("%q is not one of the options", answer)
+		}
+	case QuestionTypeMultiChoice:
+		var answers []string
+		if err := json.Unmarshal(raw, &answers); err != nil {
+			return errors.New("answer must be a list of options")
+		}
+		if q.Required && len(answers) == 0 {
+			return ErrAnswerRequired
+		}
+		seen := make(map[string]bool, len(answers))
+		for _, answer := range answers {
+			if !q.hasOption(answer) {
+				return fmt.Errorf("%q is not one of the options", answer)
+			}
+			if seen[answer] {
+				return fmt.Errorf("%q was selected more than once", answer)
+			}
+			seen[answer] = true
+		}
+	}
+
+	return nil
+}
+
+func (q *JobQuestion) hasOption(value string) bool {
+	for _, option := range q.Options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmptyAnswer reports whether raw holds no answer at all.
+func IsEmptyAnswer(raw json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(raw))
+	return trimmed == "" || trimmed == "null"
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobQuestionValidate(t *testing.T) {
+	t.Run("should require options for choice questions", func(t *testing.T) {
+		q := JobQuestion{Prompt: "Nível de inglês?", Type: QuestionTypeSingleChoice, Options: StringList{"Básico"}}
+		assert.Error(t, q.Validate())
+	})
+
+	t.Run("should reject duplicate options", func(t *testing.T) {
+		q := JobQuestion{Prompt: "Stack", Type: QuestionTypeMultiChoice, Options: StringList{"Go", "Go"}}
+		assert.Error(t, q.Validate())
+	})
+
+	t.Run("should reject options on yes/no questions", func(t *testing.T) {
+		q := JobQuestion{Prompt: "Possui visto?", Type: QuestionTypeYesNo, Options: StringList{"Sim", "Não"}}
+		assert.Error(t, q.Validate())
+	})
+
+	t.Run("should accept a valid question", func(t *testing.T) {
+		q := JobQuestion{Prompt: "Pretensão salarial?", Type: QuestionTypeNumber}
+		assert.NoError(t, q.Validate())
+	})
+}
+
+func TestJobQuestionValidateAnswer(t *testing.T) {
+	choice := JobQuestion{
+		Prompt:   "Stack",
+		Type:     QuestionTypeMultiChoice,
+		Options:  StringList{"Go", "SQL", "React"},
+		Required: true,
+	}
+
+	tests := []struct {
+		name     string
+		question JobQuestion
+		answer   string
+		wantErr  bool
+	}{
+		{"yes/no accepts booleans", JobQuestion{Type: QuestionTypeYesNo}, `true`, false},
+		{"yes/no rejects strings", JobQuestion{Type: QuestionTypeYesNo}, `"sim"`, true},
+		{"number accepts numbers", JobQuestion{Type: QuestionTypeNumber}, `8500.5`, false},
+		{"number rejects strings", JobQuestion{Type: QuestionTypeNumber}, `"8500"`, true},
+		{"single choice accepts an option", JobQuestion{Type: QuestionTypeSingleChoice, Options: StringList{"A", "B"}}, `"B"`, false},
+		{"single choice rejects unknown option", JobQuestion{Type: QuestionTypeSingleChoice, Options: StringList{"A", "B"}}, `"C"`, true},
+		{"multi choice accepts options", choice, `["Go","SQL"]`, false},
+		{"multi choice rejects repeated options", choice, `["Go","Go"]`, true},
+		{"required multi choice rejects empty list", choice, `[]`, true},
+		{"required question rejects missing answer", JobQuestion{Type: QuestionTypeText, Required: true}, ``, true},
+		{"required text rejects blank answer", JobQuestion{Type: QuestionTypeText, Required: true}, `"   "`, true},
+		{"optional question accepts null", JobQuestion{Type: QuestionTypeNumber}, `null`, false},
+		{"text rejects long answers", JobQuestion{Type: QuestionTypeText}, `"` + strings.Repeat("a", MaxTextAnswerLength+1) + `"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run("should handle "+tt.name, func(t *testing.T) {
+			err := tt.question.ValidateAnswer(json.RawMessage(tt.answer))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
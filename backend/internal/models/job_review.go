@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobReviewDecision string
+
+const (
+	JobReviewApproved JobReviewDecision = "approved"
+	JobReviewRejected JobReviewDecision = "rejected"
+)
+
+type JobReview struct {
+	ID         uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID      uuid.UUID         `gorm:"type:uuid;not null;index" json:"job_id"`
+	ReviewerID uuid.UUID         `gorm:"type:uuid;not null" json:"reviewer_id"`
+	Decision   JobReviewDecision `gorm:"type:varchar(20);not null" json:"decision"`
+	Comment    string            `gorm:"type:text" json:"comment"`
+	CreatedAt  time.Time         `json:"created_at"`
+
+	Reviewer User `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+}
+
+type JobReviewResponse struct {
+	ID         uuid.UUID         `json:"id"`
+	JobID      uuid.UUID         `json:"job_id"`
+	ReviewerID uuid.UUID         `json:"reviewer_id"`
+	Decision   JobReviewDecision `json:"decision"`
+	Comment    string            `json:"comment"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Reviewer   *UserResponse     `json:"reviewer,omitempty"`
+}
+
+func (r *JobReview) ToResponse() JobReviewResponse {
+	resp := JobReviewResponse{
+		ID:         r.ID,
+		JobID:      r.JobID,
+		ReviewerID: r.ReviewerID,
+		Decision:   r.Decision,
+		Comment:    r.Comment,
+		CreatedAt:  r.CreatedAt,
+	}
+
+	if r.Reviewer.ID != uuid.Nil {
+		reviewerResp := r.Reviewer.ToResponse()
+		resp.Reviewer = &reviewerResp
+	}
+
+	return resp
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrRevisionImmutable = errors.New("job revisions are immutable")
+
+// JobSnapshot is the content of a job posting as candidates saw it.
+type JobSnapshot struct {
+	Title               string       `json:"title"`
+	Description         string       `json:"description"`
+	SalaryMin           *float64     `json:"salary_min"`
+	SalaryMax           *float64     `json:"salary_max"`
+	SalaryCurrency      string       `json:"salary_currency"`
+	SalaryPeriod        SalaryPeriod `json:"salary_period"`
+	SalaryNegotiable    bool         `json:"salary_negotiable"`
+	Location            string       `json:"location"`
+	Type                JobType      `json:"type"`
+	ContractType        ContractType `json:"contract_type"`
+	Status              JobStatus    `json:"status"`
+	Openings            int          `json:"openings"`
+	PublishAt           *time.Time   `json:"publish_at"`
+	ExpiresAt           *time.Time   `json:"expires_at"`
+	ApplicationDeadline *time.Time   `json:"application_deadline"`
+}
+
+func (s JobSnapshot) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (s *JobSnapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+// JobRevision is an immutable record of a job posting after one change.
+// System revisions were recorded by the server rather than by an editor:
+// the scheduler publishing or closing the job, or the first snapshot of a
+// job that predates revision history. They have no EditorID.
+type JobRevision struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID         uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_job_revisions_job_revision" json:"job_id"`
+	Revision      int         `gorm:"not null;uniqueIndex:idx_job_revisions_job_revision" json:"revision"`
+	EditorID      *uuid.UUID  `gorm:"type:uuid" json:"editor_id,omitempty"`
+	System        bool        `gorm:"not null;default:false" json:"system"`
+	ChangedFields StringList  `gorm:"type:jsonb;not null" json:"changed_fields"`
+	Snapshot      JobSnapshot `gorm:"type:jsonb;not null" json:"snapshot"`
+	CreatedAt     time.Time   `json:"created_at"`
+
+	Editor *User `gorm:"foreignKey:EditorID" json:"editor,omitempty"`
+}
+
+type JobRevisionResponse struct {
+	ID            uuid.UUID     `json:"id"`
+	JobID         uuid.UUID     `json:"job_id"`
+	Revision      int           `json:"revision"`
+	EditorID      *uuid.UUID    `json:"editor_id,omitempty"`
+	Editor        *UserResponse `json:"editor,omitempty"`
+	System        bool          `json:"system"`
+	ChangedFields []string      `json:"changed_fields"`
+	Snapshot      JobSnapshot   `json:"snapshot"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// FieldChange is one field that differs between two revisions.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type JobRevisionDiff struct {
+	JobID   uuid.UUID     `json:"job_id"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+func (r *JobRevision) BeforeUpdate(tx *gorm.DB) error {
+	return ErrRevisionImmutable
+}
+
+func (r *JobRevision) BeforeDelete(tx *gorm.DB) error {
+	return ErrRevisionImmutable
+}
+
+func (r *JobRevision) ToResponse() JobRevisionResponse {
+	resp := JobRevisionResponse{
+		ID:            r.ID,
+		JobID:         r.JobID,
+		Revision:      r.Revision,
+		EditorID:      r.EditorID,
+		System:        r.System,
+		ChangedFields: r.ChangedFields,
+		Snapshot:      r.Snapshot,
+		CreatedAt:     r.CreatedAt,
+	}
+
+	if r.Editor != nil && r.Editor.ID != uuid.Nil {
+		editorResp := r.Editor.ToResponse()
+		resp.Editor = &editorResp
+	}
+
+	return resp
+}
+
+// Snapshot captures the revisioned content of the job.
+func (j *Job) Snapshot() JobSnapshot {
+	return JobSnapshot{
+		Title:               j.Title,
+		Description:         j.Description,
+		SalaryMin:           j.SalaryMin,
+		SalaryMax:           j.SalaryMax,
+		SalaryCurrency:      j.SalaryCurrency,
+		SalaryPeriod:        j.SalaryPeriod,
+		SalaryNegotiable:    j.SalaryNegotiable,
+		Location:            j.Location,
+		Type:                j.Type,
+		ContractType:        j.ContractType,
+		Status:              j.Status,
+		Openings:            j.Openings,
+		PublishAt:           j.PublishAt,
+		ExpiresAt:           j.ExpiresAt,
+		ApplicationDeadline: j.ApplicationDeadline,
+	}
+}
+
+// DiffSnapshots lists the fields whose values differ between from and to,
+// named after their JSON keys.
+func DiffSnapshots(from, to JobSnapshot) []FieldChange {
+	changes := []FieldChange{}
+
+	fromVal := reflect.ValueOf(from)
+	toVal := reflect.ValueOf(to)
+	snapshotType := fromVal.Type()
+
+	for i := 0; i < snapshotType.NumField(); i++ {
+		a := derefValue(fromVal.Field(i))
+		b := derefValue(toVal.Field(i))
+		if valuesEqual(a, b) {
+			continue
+		}
+
+		name := strings.Split(snapshotType.Field(i).Tag.Get("json"), ",")[0]
+		changes = append(changes, FieldChange{Field: name, From: a, To: b})
+	}
+
+	return changes
+}
+
+// ChangedFields returns just the names of the fields DiffSnapshots reports.
+func ChangedFields(from, to JobSnapshot) []string {
+	changes := DiffSnapshots(from, to)
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	return fields
+}
+
+func derefValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+func valuesEqual(a, b interface{}) bool {
+	ta, okA := a.(time.Time)
+	tb, okB := b.(time.Time)
+	if okA && okB {
+		return ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	salary := 5000.0
+	raised := 6000.0
+	publishAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	base := JobSnapshot{
+		Title:          "Desenvolvedor Backend Go",
+		Description:    "Descrição",
+		SalaryMin:      &salary,
+		SalaryCurrency: "BRL",
+		SalaryPeriod:   SalaryPeriodMonth,
+		Location:       "Rio de Janeiro, RJ",
+		Type:           JobTypeHybrid,
+		Status:         JobStatusOpen,
+		PublishAt:      &publishAt,
+	}
+
+	t.Run("should report no changes for equal snapshots", func(t *testing.T) {
+		samePublishAt := publishAt.In(time.FixedZone("BRT", -3*60*60))
+		sameSalary := salary
+		other := base
+		other.PublishAt = &samePublishAt
+		other.SalaryMin = &sameSalary
+
+		assert.Empty(t, DiffSnapshots(base, other))
+	})
+
+	t.Run("should report changed fields by json name", func(t *testing.T) {
+		other := base
+		other.Title = "Desenvolvedor Backend Go Sênior"
+		other.SalaryMin = &raised
+		other.PublishAt = nil
+
+		changes := DiffSnapshots(base, other)
+
+		assert.Equal(t, []FieldChange{
+			{Field: "title", From: "Desenvolvedor Backend Go", To: "Desenvolvedor Backend Go Sênior"},
+			{Field: "salary_min", From: 5000.0, To: 6000.0},
+			{Field: "publish_at", From: publishAt, To: nil},
+		}, changes)
+	})
+
+	t.Run("should list every set field against an empty snapshot", func(t *testing.T) {
+		fields := ChangedFields(JobSnapshot{}, base)
+
+		assert.ElementsMatch(t, []string{
+			"title", "description", "salary_min", "salary_currency", "salary_period",
+			"location", "type", "status", "publish_at",
+		}, fields)
+	})
+}
+
+func TestJobRevisionToResponse(t *testing.T) {
+	t.Run("should mark system revisions, which have no editor", func(t *testing.T) {
+		revision := JobRevision{Revision: 2, System: true, ChangedFields: StringList{"status"}}
+
+		resp := revision.ToResponse()
+
+		assert.True(t, resp.System)
+		assert.Nil(t, resp.EditorID)
+		assert.Nil(t, resp.Editor)
+	})
+}
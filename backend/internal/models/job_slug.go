@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+)
+
+// defaultJobSlug names jobs whose title has nothing to make a slug from.
+const defaultJobSlug = "vaga"
+
+// ReservedJobSlugs are paths under /api/jobs that a job slug would shadow.
+var ReservedJobSlugs = []string{"my-jobs", "trash", "import", "lint", "from-template"}
+
+// JobSlug is a slug a job used before its current one. Links with it keep
+// working by redirecting to the job's current slug, so a slug is never
+// handed to another job.
+type JobSlug struct {
+	Slug      string    `gorm:"type:varchar(120);primaryKey" json:"slug"`
+	JobID     uuid.UUID `gorm:"type:uuid;not null;index" json:"job_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JobSlugBase is the slug a job with title gets unless another job has it.
+func JobSlugBase(title string) string {
+	if slug := utils.Slugify(title); slug != "" {
+		return slug
+	}
+	return defaultJobSlug
+}
+
+// UsedJobSlugs returns the set of slugs new jobs may not take: taken plus
+// the reserved ones.
+func UsedJobSlugs(taken []string) map[string]bool {
+	used := make(map[string]bool, len(taken)+len(ReservedJobSlugs))
+	for _, slug := range ReservedJobSlugs {
+		used[slug] = true
+	}
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	return used
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSlugBase(t *testing.T) {
+	t.Run("should make the slug from the title", func(t *testing.T) {
+		assert.Equal(t, "engenheira-de-dados-pleno", JobSlugBase("Engenheira de Dados (Pleno)"))
+	})
+
+	t.Run("should fall back when the title has no letters or digits", func(t *testing.T) {
+		assert.Equal(t, defaultJobSlug, JobSlugBase("???"))
+	})
+}
+
+func TestUsedJobSlugs(t *testing.T) {
+	t.Run("should include the taken and reserved slugs", func(t *testing.T) {
+		used := UsedJobSlugs([]string{"backend"})
+
+		assert.True(t, used["backend"])
+		assert.True(t, used["my-jobs"])
+		assert.True(t, used["trash"])
+		assert.False(t, used["frontend"])
+	})
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeamRole string
+type JobCapability string
+
+const (
+	TeamRoleOwner         TeamRole = "owner"
+	TeamRoleRecruiter     TeamRole = "recruiter"
+	TeamRoleHiringManager TeamRole = "hiring_manager"
+	TeamRoleInterviewer   TeamRole = "interviewer"
+
+	// CapabilityViewJob covers the internal views of a job: revisions,
+	// reviews, screening setup and the hiring team.
+	CapabilityViewJob          JobCapability = "view_job"
+	CapabilityEditJob          JobCapability = "edit_job"
+	CapabilityDeleteJob        JobCapability = "delete_job"
+	CapabilityManageTeam       JobCapability = "manage_team"
+	CapabilityViewApplications JobCapability = "view_applications"
+	CapabilityManageApplicants JobCapability = "manage_applications"
+)
+
+var teamRoleCapabilities = map[TeamRole][]JobCapability{
+	TeamRoleOwner: {
+		CapabilityViewJob, CapabilityEditJob, CapabilityDeleteJob, CapabilityManageTeam,
+		CapabilityViewApplications, CapabilityManageApplicants,
+	},
+	TeamRoleRecruiter: {
+		CapabilityViewJob, CapabilityEditJob, CapabilityViewApplications, CapabilityManageApplicants,
+	},
+	TeamRoleHiringManager: {
+		CapabilityViewJob, CapabilityViewApplications, CapabilityManageApplicants,
+	},
+	TeamRoleInterviewer: {
+		CapabilityViewJob, CapabilityViewApplications,
+	},
+}
+
+// ValidTeamRole reports whether r is one of the hiring team roles.
+func ValidTeamRole(r TeamRole) bool {
+	_, ok := teamRoleCapabilities[r]
+	return ok
+}
+
+// Can reports whether members with role r have capability c.
+func (r TeamRole) Can(c JobCapability) bool {
+	for _, capability := range teamRoleCapabilities[r] {
+		if capability == c {
+			return true
+		}
+	}
+	return false
+}
+
+// JobTeamMember gives a user a role on a job's hiring team. The job's
+// creator (Job.RecruiterID) is always an owner and has no row here.
+type JobTeamMember struct {
+	JobID     uuid.UUID  `gorm:"type:uuid;primaryKey" json:"job_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Role      TeamRole   `gorm:"type:varchar(20);not null" json:"role"`
+	AddedByID *uuid.UUID `gorm:"type:uuid" json:"added_by_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+type JobTeamMemberResponse struct {
+	UserID    uuid.UUID     `json:"user_id"`
+	Role      TeamRole      `json:"role"`
+	Creator   bool          `json:"creator"`
+	CreatedAt time.Time     `json:"created_at"`
+	User      *UserResponse `json:"user,omitempty"`
+}
+
+func (m *JobTeamMember) ToResponse() JobTeamMemberResponse {
+	resp := JobTeamMemberResponse{
+		UserID:    m.UserID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.User.ID != uuid.Nil {
+		userResp := m.User.ToResponse()
+		resp.User = &userResp
+	}
+	return resp
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamRoleCan(t *testing.T) {
+	t.Run("should give owners every capability", func(t *testing.T) {
+		for _, capability := range []JobCapability{
+			CapabilityViewJob, CapabilityEditJob, CapabilityDeleteJob, CapabilityManageTeam,
+			CapabilityViewApplications, CapabilityManageApplicants,
+		} {
+			assert.True(t, TeamRoleOwner.Can(capability), capability)
+		}
+	})
+
+	t.Run("should let recruiters edit but not delete or manage the team", func(t *testing.T) {
+		assert.True(t, TeamRoleRecruiter.Can(CapabilityEditJob))
+		assert.False(t, TeamRoleRecruiter.Can(CapabilityDeleteJob))
+		assert.False(t, TeamRoleRecruiter.Can(CapabilityManageTeam))
+	})
+
+	t.Run("should let hiring managers manage applications without editing the job", func(t *testing.T) {
+		assert.True(t, TeamRoleHiringManager.Can(CapabilityManageApplicants))
+		assert.False(t, TeamRoleHiringManager.Can(CapabilityEditJob))
+	})
+
+	t.Run("should limit interviewers to viewing", func(t *testing.T) {
+		assert.True(t, TeamRoleInterviewer.Can(CapabilityViewApplications))
+		assert.False(t, TeamRoleInterviewer.Can(CapabilityManageApplicants))
+	})
+
+	t.Run("should deny unknown roles", func(t *testing.T) {
+		assert.False(t, TeamRole("guest").Can(CapabilityViewJob))
+		assert.False(t, ValidTeamRole("guest"))
+	})
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TemplateVisibility string
+
+const (
+	// TemplateVisibilityPrivate templates are only visible to their owner.
+	TemplateVisibilityPrivate TemplateVisibility = "private"
+	// TemplateVisibilityCompany templates are shared with every recruiter.
+	TemplateVisibilityCompany TemplateVisibility = "company"
+)
+
+type JobTemplate struct {
+	ID               uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OwnerID          uuid.UUID          `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Visibility       TemplateVisibility `gorm:"type:varchar(20);not null;default:'private'" json:"visibility"`
+	Name             string             `gorm:"not null" json:"name"`
+	Title            string             `json:"title"`
+	Description      string             `gorm:"type:text" json:"description"`
+	SalaryMin        *float64           `json:"salary_min,omitempty"`
+	SalaryMax        *float64           `json:"salary_max,omitempty"`
+	SalaryCurrency   string             `gorm:"type:varchar(3)" json:"salary_currency"`
+	SalaryPeriod     SalaryPeriod       `gorm:"type:varchar(10)" json:"salary_period"`
+	SalaryNegotiable bool               `gorm:"not null;default:false" json:"salary_negotiable"`
+	Location         string             `json:"location"`
+	Type             JobType            `gorm:"type:varchar(20)" json:"type"`
+	ContractType     ContractType       `gorm:"type:varchar(20)" json:"contract_type,omitempty"`
+	Locale           Locale             `gorm:"type:varchar(10)" json:"locale,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt     `gorm:"index" json:"-"`
+
+	Owner User `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+}
+
+type JobTemplateResponse struct {
+	ID               uuid.UUID          `json:"id"`
+	OwnerID          uuid.UUID          `json:"owner_id"`
+	Visibility       TemplateVisibility `json:"visibility"`
+	Name             string             `json:"name"`
+	Title            string             `json:"title"`
+	Description      string             `json:"description"`
+	SalaryMin        *float64           `json:"salary_min,omitempty"`
+	SalaryMax        *float64           `json:"salary_max,omitempty"`
+	SalaryCurrency   string             `json:"salary_currency,omitempty"`
+	SalaryPeriod     SalaryPeriod       `json:"salary_period,omitempty"`
+	SalaryNegotiable bool               `json:"salary_negotiable"`
+	Location         string             `json:"location"`
+	Type             JobType            `json:"type,omitempty"`
+	ContractType     ContractType       `json:"contract_type,omitempty"`
+	Locale           Locale             `json:"locale,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+func (t *JobTemplate) ToResponse() JobTemplateResponse {
+	return JobTemplateResponse{
+		ID:               t.ID,
+		OwnerID:          t.OwnerID,
+		Visibility:       t.Visibility,
+		Name:             t.Name,
+		Title:            t.Title,
+		Description:      t.Description,
+		SalaryMin:        t.SalaryMin,
+		SalaryMax:        t.SalaryMax,
+		SalaryCurrency:   t.SalaryCurrency,
+		SalaryPeriod:     t.SalaryPeriod,
+		SalaryNegotiable: t.SalaryNegotiable,
+		Location:         t.Location,
+		Type:             t.Type,
+		ContractType:     t.ContractType,
+		Locale:           t.Locale,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
+
+// VisibleTo reports whether userID may see and use the template.
+func (t *JobTemplate) VisibleTo(userID uuid.UUID) bool {
+	return t.OwnerID == userID || t.Visibility == TemplateVisibilityCompany
+}
+
+// NewJob builds a draft job owned by recruiterID from the template content.
+func (t *JobTemplate) NewJob(recruiterID uuid.UUID) *Job {
+	return &Job{
+		RecruiterID:      recruiterID,
+		Title:            t.Title,
+		Description:      t.Description,
+		SalaryMin:        t.SalaryMin,
+		SalaryMax:        t.SalaryMax,
+		SalaryCurrency:   t.SalaryCurrency,
+		SalaryPeriod:     t.SalaryPeriod,
+		SalaryNegotiable: t.SalaryNegotiable,
+		Location:         t.Location,
+		Type:             t.Type,
+		ContractType:     t.ContractType,
+		Locale:           t.Locale,
+		Status:           JobStatusDraft,
+	}
+}
+
+// SetContent copies the posting content of job into the template.
+func (t *JobTemplate) SetContent(job *Job) {
+	t.Title = job.Title
+	t.Description = job.Description
+	t.SalaryMin = job.SalaryMin
+	t.SalaryMax = job.SalaryMax
+	t.SalaryCurrency = job.SalaryCurrency
+	t.SalaryPeriod = job.SalaryPeriod
+	t.SalaryNegotiable = job.SalaryNegotiable
+	t.Location = job.Location
+	t.Type = job.Type
+	t.ContractType = job.ContractType
+	t.Locale = job.Locale
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTemplate(t *testing.T) {
+	salary := 9000.0
+	ownerID := uuid.New()
+	template := &JobTemplate{
+		ID:               uuid.New(),
+		OwnerID:          ownerID,
+		Visibility:       TemplateVisibilityPrivate,
+		Name:             "Backend",
+		Title:            "Desenvolvedor Go",
+		Description:      "APIs em Go",
+		SalaryMin:        &salary,
+		SalaryCurrency:   "BRL",
+		SalaryPeriod:     SalaryPeriodMonth,
+		SalaryNegotiable: true,
+		Location:         "Recife, PE",
+		Type:             JobTypeHybrid,
+		ContractType:     ContractTypeFullTime,
+		Locale:           LocaleEn,
+	}
+
+	t.Run("should only be visible to its owner while private", func(t *testing.T) {
+		assert.True(t, template.VisibleTo(ownerID))
+		assert.False(t, template.VisibleTo(uuid.New()))
+
+		shared := *template
+		shared.Visibility = TemplateVisibilityCompany
+		assert.True(t, shared.VisibleTo(uuid.New()))
+	})
+
+	t.Run("should build a draft with the template content", func(t *testing.T) {
+		recruiterID := uuid.New()
+
+		job := template.NewJob(recruiterID)
+
+		assert.Equal(t, recruiterID, job.RecruiterID)
+		assert.Equal(t, JobStatusDraft, job.Status)
+		assert.Equal(t, uuid.Nil, job.ID)
+		assert.Equal(t, "Desenvolvedor Go", job.Title)
+		assert.Equal(t, &salary, job.SalaryMin)
+		assert.True(t, job.SalaryNegotiable)
+		assert.Equal(t, JobTypeHybrid, job.Type)
+		assert.Equal(t, ContractTypeFullTime, job.ContractType)
+		assert.Equal(t, LocaleEn, job.Locale)
+	})
+
+	t.Run("should copy the content of a job back into the template", func(t *testing.T) {
+		updated := *template
+		job := template.NewJob(ownerID)
+		job.Title = "Desenvolvedor Rust"
+		job.Type = JobTypeRemote
+		job.ContractType = ContractTypeContractor
+
+		updated.SetContent(job)
+
+		assert.Equal(t, "Desenvolvedor Rust", updated.Title)
+		assert.Equal(t, JobTypeRemote, updated.Type)
+		assert.Equal(t, ContractTypeContractor, updated.ContractType)
+		assert.Equal(t, "Backend", updated.Name)
+		assert.Equal(t, TemplateVisibilityPrivate, updated.Visibility)
+	})
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobOpenings(t *testing.T) {
+	t.Run("should report remaining openings", func(t *testing.T) {
+		job := Job{Openings: 3, Filled: 1}
+
+		assert.Equal(t, 2, job.Remaining())
+		assert.False(t, job.IsFilled())
+	})
+
+	t.Run("should be filled when every opening is taken", func(t *testing.T) {
+		job := Job{Openings: 2, Filled: 2}
+
+		assert.Equal(t, 0, job.Remaining())
+		assert.True(t, job.IsFilled())
+	})
+
+	t.Run("should not report negative remaining when openings are lowered", func(t *testing.T) {
+		job := Job{Openings: 1, Filled: 3}
+
+		assert.Equal(t, 0, job.Remaining())
+		assert.True(t, job.IsFilled())
+	})
+
+	t.Run("should default openings to one on save", func(t *testing.T) {
+		job := Job{Title: "Dev", Description: "Desc", Location: "Remoto", Type: JobTypeRemote}
+
+		assert.NoError(t, job.BeforeSave(nil))
+		assert.Equal(t, 1, job.Openings)
+	})
+
+	t.Run("should reject negative openings", func(t *testing.T) {
+		job := Job{Title: "Dev", Description: "Desc", Location: "Remoto", Type: JobTypeRemote, Openings: -1}
+
+		assert.ErrorIs(t, job.BeforeSave(nil), ErrInvalidOpenings)
+	})
+}
+
+func TestJobDescription(t *testing.T) {
+	t.Run("should render the markdown description in the response", func(t *testing.T) {
+		job := Job{Description: "**Remoto**\n<script>alert(1)</script>"}
+
+		resp := job.ToResponse(false)
+
+		assert.Equal(t, job.Description, resp.Description)
+		assert.Equal(t, "<p><strong>Remoto</strong><br/>\n&lt;script&gt;alert(1)&lt;/script&gt;</p>\n", resp.DescriptionHTML)
+	})
+
+	t.Run("should keep the plain-text description in sync on save", func(t *testing.T) {
+		job := Job{Title: "Dev", Description: "## Sobre\n- **Go**", Location: "Remoto", Type: JobTypeRemote}
+
+		assert.NoError(t, job.BeforeSave(nil))
+		assert.Equal(t, "Sobre\n\nGo", job.DescriptionText)
+	})
+}
+
+func TestJobApplicationDeadline(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	t.Run("should close applications at the earlier of deadline and expiry", func(t *testing.T) {
+		job := Job{ApplicationDeadline: at(24 * time.Hour), ExpiresAt: at(72 * time.Hour)}
+		assert.Equal(t, job.ApplicationDeadline, job.ApplicationsCloseAt())
+
+		job.ExpiresAt = at(12 * time.Hour)
+		assert.Equal(t, job.ExpiresAt, job.ApplicationsCloseAt())
+
+		job.ExpiresAt = nil
+		assert.Equal(t, job.ApplicationDeadline, job.ApplicationsCloseAt())
+	})
+
+	t.Run("should stop accepting applications once the deadline passes", func(t *testing.T) {
+		job := Job{Status: JobStatusOpen, ApplicationDeadline: at(time.Hour)}
+
+		assert.True(t, job.AcceptsApplications(now))
+		assert.False(t, job.DeadlinePassed(now))
+
+		later := now.Add(2 * time.Hour)
+		assert.True(t, job.DeadlinePassed(later))
+		assert.False(t, job.AcceptsApplications(later))
+		assert.True(t, job.IsPubliclyVisible(later))
+	})
+
+	t.Run("should report jobs whose deadline falls inside the window as closing", func(t *testing.T) {
+		job := Job{Status: JobStatusOpen, ApplicationDeadline: at(24 * time.Hour), ExpiresAt: at(240 * time.Hour)}
+
+		assert.True(t, job.ClosesWithin(now, 48*time.Hour))
+		assert.False(t, job.ClosesWithin(now, 12*time.Hour))
+	})
+
+	t.Run("should reject deadlines outside the publication window", func(t *testing.T) {
+		job := Job{PublishAt: at(24 * time.Hour), ExpiresAt: at(72 * time.Hour)}
+
+		job.ApplicationDeadline = at(12 * time.Hour)
+		assert.ErrorIs(t, job.ValidateSchedule(), ErrInvalidDeadline)
+
+		job.ApplicationDeadline = at(96 * time.Hour)
+		assert.ErrorIs(t, job.ValidateSchedule(), ErrInvalidDeadline)
+
+		job.ApplicationDeadline = at(72 * time.Hour)
+		assert.NoError(t, job.ValidateSchedule())
+	})
+}
+
+func TestJobCloneAsDraft(t *testing.T) {
+	t.Run("should copy the posting content but not its state or schedule", func(t *testing.T) {
+		salary := 9000.0
+		published := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+		expires := published.AddDate(0, 1, 0)
+		job := &Job{
+			ID:               uuid.New(),
+			RecruiterID:      uuid.New(),
+			Slug:             "desenvolvedor-go",
+			Title:            "Desenvolvedor Go",
+			Description:      "APIs em Go",
+			SalaryMin:        &salary,
+			SalaryNegotiable: true,
+			Location:         "Recife, PE",
+			Type:             JobTypeOnsite,
+			ContractType:     ContractTypeTemporary,
+			Status:           JobStatusClosed,
+			Openings:         3,
+			Filled:           3,
+			AutoRejectOnFill: true,
+			FillMessage:      "Vagas preenchidas",
+			PublishedAt:      &published,
+			ExpiresAt:        &expires,
+		}
+		recruiterID := uuid.New()
+
+		clone := job.CloneAsDraft(recruiterID)
+
+		assert.Equal(t, uuid.Nil, clone.ID)
+		assert.Empty(t, clone.Slug)
+		assert.Equal(t, recruiterID, clone.RecruiterID)
+		assert.Equal(t, JobStatusDraft, clone.Status)
+		assert.Equal(t, "Desenvolvedor Go", clone.Title)
+		assert.Equal(t, &salary, clone.SalaryMin)
+		assert.Equal(t, ContractTypeTemporary, clone.ContractType)
+		assert.Equal(t, 3, clone.Openings)
+		assert.Zero(t, clone.Filled)
+		assert.True(t, clone.AutoRejectOnFill)
+		assert.Nil(t, clone.PublishedAt)
+		assert.Nil(t, clone.ExpiresAt)
+	})
+}
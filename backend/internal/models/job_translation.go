@@ -0,0 +1,155 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+	"gorm.io/gorm"
+)
+
+// Locale is a BCP 47 language tag a job can be published in.
+type Locale string
+
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEs   Locale = "es"
+	LocaleEn   Locale = "en"
+
+	// DefaultLocale is the language jobs are written in unless they say
+	// otherwise, and the fallback when no translation matches.
+	DefaultLocale = LocalePtBR
+)
+
+// localeSearchConfigs maps each supported locale to the PostgreSQL text
+// search configuration that stems it.
+var localeSearchConfigs = map[Locale]string{
+	LocalePtBR: "portuguese",
+	LocaleEs:   "spanish",
+	LocaleEn:   "english",
+}
+
+var ErrUnsupportedLocale = errors.New("locale must be one of en, es, pt-BR")
+
+// SupportedLocales returns the supported locales in a stable order.
+func SupportedLocales() []Locale {
+	locales := make([]Locale, 0, len(localeSearchConfigs))
+	for locale := range localeSearchConfigs {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
+
+// SearchConfig returns the text search configuration for the locale,
+// falling back to the default locale's.
+func (l Locale) SearchConfig() string {
+	if config, ok := localeSearchConfigs[l]; ok {
+		return config
+	}
+	return localeSearchConfigs[DefaultLocale]
+}
+
+// ParseLocale matches a language tag to a supported locale by its primary
+// language, so "pt", "pt-PT" and "PT-br" all select pt-BR and "es-AR"
+// selects es.
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", false
+	}
+	primary := strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+	for locale := range localeSearchConfigs {
+		if strings.ToLower(strings.SplitN(string(locale), "-", 2)[0]) == primary {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// NegotiateLocale picks the locale to serve: an explicit lang parameter
+// wins, then the Accept-Language preference with the highest weight, then
+// the default locale.
+func NegotiateLocale(lang, acceptLanguage string) Locale {
+	if locale, ok := ParseLocale(lang); ok {
+		return locale
+	}
+
+	best, bestWeight := DefaultLocale, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if locale, ok := ParseLocale(fields[0]); ok && weight > bestWeight {
+			best, bestWeight = locale, weight
+		}
+	}
+	return best
+}
+
+// JobTranslation is a job's title and description in another locale. Both
+// it and the job itself are indexed for full-text search with their
+// locale's configuration.
+type JobTranslation struct {
+	JobID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"job_id"`
+	Locale          Locale    `gorm:"type:varchar(10);primaryKey" json:"locale"`
+	Title           string    `gorm:"not null" json:"title"`
+	Description     string    `gorm:"type:text;not null" json:"description"`
+	DescriptionText string    `gorm:"type:text" json:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// BeforeSave keeps the plain-text description used by search in sync.
+func (t *JobTranslation) BeforeSave(tx *gorm.DB) error {
+	t.DescriptionText = markdown.ToText(t.Description)
+	return nil
+}
+
+// Translate switches the response to its translation into locale or,
+// failing that, into the default locale. Responses already in the wanted
+// locale, or without a matching translation, keep the job's own content.
+func (r *JobResponse) Translate(locale Locale, translations map[Locale]JobTranslation) {
+	for _, candidate := range []Locale{locale, DefaultLocale} {
+		if candidate == r.Locale {
+			return
+		}
+		if t, ok := translations[candidate]; ok {
+			r.Title, r.Description, r.Locale = t.Title, t.Description, candidate
+			r.DescriptionHTML = markdown.ToHTML(t.Description)
+			return
+		}
+	}
+}
+
+// ContentLocale is the locale the job's own title and description are
+// written in.
+func (j *Job) ContentLocale() Locale {
+	if j.Locale == "" {
+		return DefaultLocale
+	}
+	return j.Locale
+}
+
+// SearchConfigSQL is a SQL expression mapping the locale in column to its
+// text search configuration. Unknown locales use the default locale's.
+func SearchConfigSQL(column string) string {
+	var b strings.Builder
+	b.WriteString("CASE " + column)
+	for _, locale := range SupportedLocales() {
+		b.WriteString(" WHEN '" + string(locale) + "' THEN '" + locale.SearchConfig() + "'::regconfig")
+	}
+	b.WriteString(" ELSE '" + DefaultLocale.SearchConfig() + "'::regconfig END")
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	t.Run("should prefer the lang parameter", func(t *testing.T) {
+		assert.Equal(t, LocaleEn, NegotiateLocale("en", "es-AR,es;q=0.9"))
+	})
+
+	t.Run("should pick the highest weighted supported language", func(t *testing.T) {
+		assert.Equal(t, LocaleEs, NegotiateLocale("", "fr-FR, es-MX;q=0.8, en;q=0.5"))
+	})
+
+	t.Run("should match regional variants by primary language", func(t *testing.T) {
+		assert.Equal(t, LocalePtBR, NegotiateLocale("", "pt-PT"))
+		assert.Equal(t, LocaleEn, NegotiateLocale("EN_us", ""))
+	})
+
+	t.Run("should fall back to the default locale", func(t *testing.T) {
+		assert.Equal(t, DefaultLocale, NegotiateLocale("de", "fr, it;q=0.5"))
+		assert.Equal(t, DefaultLocale, NegotiateLocale("", ""))
+	})
+}
+
+func TestJobResponse_Translate(t *testing.T) {
+	translations := map[Locale]JobTranslation{
+		LocaleEs:   {Locale: LocaleEs, Title: "Desarrollador Go", Description: "Trabajo remoto"},
+		LocalePtBR: {Locale: LocalePtBR, Title: "Desenvolvedor Go", Description: "Trabalho remoto"},
+	}
+
+	t.Run("should serve the requested translation", func(t *testing.T) {
+		resp := JobResponse{Title: "Go Developer", Locale: LocaleEn}
+
+		resp.Translate(LocaleEs, translations)
+
+		assert.Equal(t, "Desarrollador Go", resp.Title)
+		assert.Equal(t, "<p>Trabajo remoto</p>\n", resp.DescriptionHTML)
+		assert.Equal(t, LocaleEs, resp.Locale)
+	})
+
+	t.Run("should fall back to the default locale", func(t *testing.T) {
+		resp := JobResponse{Title: "Desarrollador Go", Locale: LocaleEs}
+
+		resp.Translate(LocaleEn, map[Locale]JobTranslation{LocalePtBR: translations[LocalePtBR]})
+
+		assert.Equal(t, "Desenvolvedor Go", resp.Title)
+		assert.Equal(t, LocalePtBR, resp.Locale)
+	})
+
+	t.Run("should keep the job's own content without a translation", func(t *testing.T) {
+		resp := JobResponse{Title: "Desenvolvedor Go", Locale: LocalePtBR}
+
+		resp.Translate(LocaleEn, translations)
+
+		assert.Equal(t, "Desenvolvedor Go", resp.Title)
+		assert.Equal(t, LocalePtBR, resp.Locale)
+	})
+}
+
+func TestSearchConfigSQL(t *testing.T) {
+	t.Run("should map every locale to its text search configuration", func(t *testing.T) {
+		sql := SearchConfigSQL("locale")
+
+		assert.True(t, strings.HasPrefix(sql, "CASE locale "))
+		assert.Contains(t, sql, "WHEN 'es' THEN 'spanish'::regconfig")
+		assert.Contains(t, sql, "WHEN 'en' THEN 'english'::regconfig")
+		assert.Contains(t, sql, "ELSE 'portuguese'::regconfig END")
+	})
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TrashedJobResponse is a deleted job as listed in the trash.
+type TrashedJobResponse struct {
+	JobResponse
+	DeletedAt time.Time `json:"deleted_at"`
+	// PurgeAt is when the job will be deleted for good; it is omitted when
+	// the trash is kept forever.
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
+	// TrashedApplications counts the applications deleted along with the
+	// job, which are restored with it.
+	TrashedApplications int64 `json:"trashed_applications"`
+}
+
+// ToTrashedResponse describes the deleted job, purged retention after its
+// deletion when retention is positive.
+func (j *Job) ToTrashedResponse(retention time.Duration, applications int64) TrashedJobResponse {
+	resp := TrashedJobResponse{
+		JobResponse:         j.ToResponse(false),
+		DeletedAt:           j.DeletedAt.Time,
+		TrashedApplications: applications,
+	}
+	if retention > 0 {
+		purgeAt := j.DeletedAt.Time.Add(retention)
+		resp.PurgeAt = &purgeAt
+	}
+	return resp
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobView records that a viewer opened a job on a given day. Views are
+// deduplicated per job, viewer and day, so reloading a posting counts once.
+// ViewerKey is a hash of the candidate's ID or of the anonymous session,
+// never the raw identifier.
+type JobView struct {
+	JobID     uuid.UUID  `gorm:"type:uuid;primaryKey" json:"job_id"`
+	ViewerKey string     `gorm:"type:varchar(64);primaryKey" json:"-"`
+	Day       time.Time  `gorm:"type:date;primaryKey;index" json:"day"`
+	UserID    *uuid.UUID `gorm:"type:uuid" json:"user_id,omitempty"`
+	// Referrer is where the first view of the day came from: the ?ref=
+	// campaign tag or the host of the Referer header.
+	Referrer  string    `gorm:"type:varchar(255);not null;default:''" json:"referrer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReferrerCount struct {
+	Referrer string `json:"referrer"`
+	Views    int64  `json:"views"`
+}
+
+type JobStatsDay struct {
+	Date         string `json:"date"`
+	Views        int64  `json:"views"`
+	Applications int64  `json:"applications"`
+}
+
+// JobStats is a job's views-to-applications funnel over [From, To].
+type JobStats struct {
+	JobID          uuid.UUID       `json:"job_id"`
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	Views          int64           `json:"views"`
+	UniqueViewers  int64           `json:"unique_viewers"`
+	Applications   int64           `json:"applications"`
+	ConversionRate float64         `json:"conversion_rate"`
+	Referrers      []ReferrerCount `json:"referrers"`
+	Daily          []JobStatsDay   `json:"daily"`
+}
+
+// Conversion is the share of unique viewers who applied, rounded to four
+// decimals. It is zero when nobody viewed the job.
+func Conversion(applications, uniqueViewers int64) float64 {
+	if uniqueViewers == 0 {
+		return 0
+	}
+	return math.Round(float64(applications)/float64(uniqueViewers)*10000) / 10000
+}
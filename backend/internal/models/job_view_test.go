@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversion(t *testing.T) {
+	t.Run("should divide applications by unique viewers", func(t *testing.T) {
+		assert.Equal(t, 0.1429, Conversion(1, 7))
+	})
+
+	t.Run("should be zero without viewers", func(t *testing.T) {
+		assert.Equal(t, 0.0, Conversion(3, 0))
+	})
+}
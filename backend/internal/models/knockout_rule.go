@@ -0,0 +1,276 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type KnockoutOperator string
+type KnockoutAction string
+
+const (
+	KnockoutOperatorEquals    KnockoutOperator = "equals"
+	KnockoutOperatorNotEquals KnockoutOperator = "not_equals"
+	KnockoutOperatorGTE       KnockoutOperator = "gte"
+	KnockoutOperatorLTE       KnockoutOperator = "lte"
+	KnockoutOperatorIncludes  KnockoutOperator = "includes"
+	KnockoutOperatorExcludes  KnockoutOperator = "excludes"
+
+	KnockoutActionReject KnockoutAction = "reject"
+	KnockoutActionFlag   KnockoutAction = "flag"
+)
+
+// knockoutOperators lists the operators that make sense for each question
+// type. Free text answers cannot be used as knockout criteria.
+var knockoutOperators = map[QuestionType][]KnockoutOperator{
+	QuestionTypeYesNo:        {KnockoutOperatorEquals, KnockoutOperatorNotEquals},
+	QuestionTypeNumber:       {KnockoutOperatorEquals, KnockoutOperatorNotEquals, KnockoutOperatorGTE, KnockoutOperatorLTE},
+	QuestionTypeSingleChoice: {KnockoutOperatorEquals, KnockoutOperatorNotEquals, KnockoutOperatorIncludes, KnockoutOperatorExcludes},
+	QuestionTypeMultiChoice:  {KnockoutOperatorIncludes, KnockoutOperatorExcludes},
+}
+
+// KnockoutRule is a criterion a candidate must meet to pass automatic
+// screening. The rule fires when the candidate's answer to the question does
+// not satisfy Operator/Value; the application is then rejected or flagged
+// according to Action. Position keeps the order the rules were set in, which
+// decides between flag rules.
+type KnockoutRule struct {
+	ID               uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID            uuid.UUID        `gorm:"type:uuid;not null;index" json:"job_id"`
+	QuestionID       uuid.UUID        `gorm:"type:uuid;not null" json:"question_id"`
+	Position         int              `gorm:"not null;default:0" json:"position"`
+	Operator         KnockoutOperator `gorm:"type:varchar(20);not null" json:"operator"`
+	Value            JSONValue        `gorm:"type:jsonb;not null" json:"value"`
+	Action           KnockoutAction   `gorm:"type:varchar(10);not null" json:"action"`
+	Message          string           `gorm:"type:text" json:"message"`
+	NoticeDelayHours int              `gorm:"not null;default:0" json:"notice_delay_hours"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+type KnockoutRuleResponse struct {
+	ID               uuid.UUID        `json:"id"`
+	QuestionID       uuid.UUID        `json:"question_id"`
+	Position         int              `json:"position"`
+	Operator         KnockoutOperator `json:"operator"`
+	Value            JSONValue        `json:"value" swaggertype:"object"`
+	Action           KnockoutAction   `json:"action"`
+	Message          string           `json:"message,omitempty"`
+	NoticeDelayHours int              `json:"notice_delay_hours"`
+}
+
+func (r *KnockoutRule) ToResponse() KnockoutRuleResponse {
+	return KnockoutRuleResponse{
+		ID:               r.ID,
+		QuestionID:       r.QuestionID,
+		Position:         r.Position,
+		Operator:         r.Operator,
+		Value:            r.Value,
+		Action:           r.Action,
+		Message:          r.Message,
+		NoticeDelayHours: r.NoticeDelayHours,
+	}
+}
+
+// Validate checks that the rule can be evaluated against answers to q.
+func (r *KnockoutRule) Validate(q *JobQuestion) error {
+	if r.Action != KnockoutActionReject && r.Action != KnockoutActionFlag {
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if r.NoticeDelayHours < 0 {
+		return errors.New("notice_delay_hours must not be negative")
+	}
+
+	allowed := false
+	for _, op := range knockoutOperators[q.Type] {
+		if op == r.Operator {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("operator %q cannot be used with %s questions", r.Operator, q.Type)
+	}
+
+	switch q.Type {
+	case QuestionTypeYesNo:
+		var v bool
+		if err := json.Unmarshal(r.Value, &v); err != nil {
+			return errors.New("value must be true or false")
+		}
+	case QuestionTypeNumber:
+		var v float64
+		if err := json.Unmarshal(r.Value, &v); err != nil {
+			return errors.New("value must be a number")
+		}
+	case QuestionTypeSingleChoice, QuestionTypeMultiChoice:
+		if r.Operator == KnockoutOperatorEquals || r.Operator == KnockoutOperatorNotEquals {
+			var v string
+			if err := json.Unmarshal(r.Value, &v); err != nil {
+				return errors.New("value must be a single option")
+			}
+		}
+		values, err := r.optionValues()
+		if err != nil || len(values) == 0 {
+			return errors.New("value must be an option or a list of options")
+		}
+		for _, v := range values {
+			if !q.hasOption(v) {
+				return fmt.Errorf("%q is not one of the options", v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fires reports whether the rule rejects or flags the answer given to q. A
+// missing answer never satisfies the criterion.
+func (r *KnockoutRule) Fires(q *JobQuestion, answer json.RawMessage) bool {
+	if IsEmptyAnswer(answer) {
+		return true
+	}
+	return !r.satisfiedBy(q, answer)
+}
+
+func (r *KnockoutRule) satisfiedBy(q *JobQuestion, answer json.RawMessage) bool {
+	switch r.Operator {
+	case KnockoutOperatorEquals, KnockoutOperatorNotEquals:
+		var got, want interface{}
+		if json.Unmarshal(answer, &got) != nil || json.Unmarshal(r.Value, &want) != nil {
+			return false
+		}
+		return (got == want) == (r.Operator == KnockoutOperatorEquals)
+	case KnockoutOperatorGTE, KnockoutOperatorLTE:
+		var got, want float64
+		if json.Unmarshal(answer, &got) != nil || json.Unmarshal(r.Value, &want) != nil {
+			return false
+		}
+		if r.Operator == KnockoutOperatorGTE {
+			return got >= want
+		}
+		return got <= want
+	case KnockoutOperatorIncludes, KnockoutOperatorExcludes:
+		values, err := r.optionValues()
+		if err != nil {
+			return false
+		}
+		selected, ok := selectedOptions(answer)
+		if !ok {
+			return false
+		}
+		if r.Operator == KnockoutOperatorExcludes {
+			return includesNone(selected, values)
+		}
+		// A single choice answer holds one option, so "includes" means the
+		// answer is one of the listed values; for multi choice every listed
+		// value must be selected.
+		if q.Type == QuestionTypeSingleChoice && len(selected) == 1 {
+			return containsString(values, selected[0])
+		}
+		return includesAll(selected, values)
+	}
+	return false
+}
+
+// optionValues decodes Value as either a single option or a list of options.
+func (r *KnockoutRule) optionValues() ([]string, error) {
+	var single string
+	if err := json.Unmarshal(r.Value, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	err := json.Unmarshal(r.Value, &list)
+	return list, err
+}
+
+// selectedOptions decodes a choice answer as a list of selected options.
+func selectedOptions(answer json.RawMessage) ([]string, bool) {
+	var single string
+	if err := json.Unmarshal(answer, &single); err == nil {
+		return []string{single}, true
+	}
+	var list []string
+	if err := json.Unmarshal(answer, &list); err != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+func includesAll(selected, values []string) bool {
+	for _, v := range values {
+		if !containsString(selected, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func includesNone(selected, values []string) bool {
+	for _, v := range values {
+		if containsString(selected, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateKnockout runs rules against the answers keyed by question ID and
+// returns the rule that decides the outcome, or nil when none fires. A
+// firing reject rule takes precedence over flag rules; otherwise rules are
+// considered in order.
+func EvaluateKnockout(rules []KnockoutRule, questions []JobQuestion, answers map[uuid.UUID]json.RawMessage) *KnockoutRule {
+	byID := make(map[uuid.UUID]*JobQuestion, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	var flagged *KnockoutRule
+	for i := range rules {
+		rule := &rules[i]
+		question, ok := byID[rule.QuestionID]
+		if !ok || !rule.Fires(question, answers[rule.QuestionID]) {
+			continue
+		}
+		if rule.Action == KnockoutActionReject {
+			return rule
+		}
+		if flagged == nil {
+			flagged = rule
+		}
+	}
+	return flagged
+}
+
+// ApplyKnockout records the outcome of a fired rule on the application. A
+// rejection notice can be held back for the rule's NoticeDelayHours.
+func (a *Application) ApplyKnockout(rule *KnockoutRule, now time.Time) {
+	a.KnockoutRuleID = &rule.ID
+	a.KnockoutReason = rule.Message
+
+	if rule.Action == KnockoutActionFlag {
+		a.Flagged = true
+		return
+	}
+
+	a.Status = ApplicationStatusRejected
+	if rule.NoticeDelayHours > 0 {
+		visibleAt := now.Add(time.Duration(rule.NoticeDelayHours) * time.Hour)
+		a.RejectionVisibleAt = &visibleAt
+	}
+}
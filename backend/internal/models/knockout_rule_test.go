@@ -0,0 +1,113 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnockoutRuleFires(t *testing.T) {
+	yesNo := JobQuestion{Type: QuestionTypeYesNo}
+	number := JobQuestion{Type: QuestionTypeNumber}
+	single := JobQuestion{Type: QuestionTypeSingleChoice, Options: StringList{"Júnior", "Pleno", "Sênior"}}
+	multi := JobQuestion{Type: QuestionTypeMultiChoice, Options: StringList{"Go", "SQL", "React"}}
+
+	tests := []struct {
+		name     string
+		question JobQuestion
+		operator KnockoutOperator
+		value    string
+		answer   string
+		fires    bool
+	}{
+		{"yes/no equals met", yesNo, KnockoutOperatorEquals, `true`, `true`, false},
+		{"yes/no equals not met", yesNo, KnockoutOperatorEquals, `true`, `false`, true},
+		{"number gte met", number, KnockoutOperatorGTE, `3`, `5`, false},
+		{"number gte not met", number, KnockoutOperatorGTE, `3`, `2.5`, true},
+		{"number lte met", number, KnockoutOperatorLTE, `10000`, `10000`, false},
+		{"single includes one of", single, KnockoutOperatorIncludes, `["Pleno","Sênior"]`, `"Sênior"`, false},
+		{"single includes none of", single, KnockoutOperatorIncludes, `["Pleno","Sênior"]`, `"Júnior"`, true},
+		{"multi includes all", multi, KnockoutOperatorIncludes, `["Go","SQL"]`, `["SQL","Go","React"]`, false},
+		{"multi includes partial", multi, KnockoutOperatorIncludes, `["Go","SQL"]`, `["Go"]`, true},
+		{"multi excludes met", multi, KnockoutOperatorExcludes, `"React"`, `["Go"]`, false},
+		{"multi excludes not met", multi, KnockoutOperatorExcludes, `"React"`, `["React"]`, true},
+		{"missing answer", number, KnockoutOperatorGTE, `3`, ``, true},
+	}
+
+	for _, tt := range tests {
+		t.Run("should handle "+tt.name, func(t *testing.T) {
+			rule := KnockoutRule{Operator: tt.operator, Value: JSONValue(tt.value), Action: KnockoutActionReject}
+			assert.NoError(t, rule.Validate(&tt.question))
+			assert.Equal(t, tt.fires, rule.Fires(&tt.question, json.RawMessage(tt.answer)))
+		})
+	}
+}
+
+func TestKnockoutRuleValidate(t *testing.T) {
+	t.Run("should reject operators that do not fit the question type", func(t *testing.T) {
+		rule := KnockoutRule{Operator: KnockoutOperatorGTE, Value: JSONValue(`true`), Action: KnockoutActionReject}
+		assert.Error(t, rule.Validate(&JobQuestion{Type: QuestionTypeYesNo}))
+	})
+
+	t.Run("should reject values that are not options", func(t *testing.T) {
+		rule := KnockoutRule{Operator: KnockoutOperatorIncludes, Value: JSONValue(`"Rust"`), Action: KnockoutActionFlag}
+		assert.Error(t, rule.Validate(&JobQuestion{Type: QuestionTypeMultiChoice, Options: StringList{"Go", "SQL"}}))
+	})
+
+	t.Run("should reject rules on text questions", func(t *testing.T) {
+		rule := KnockoutRule{Operator: KnockoutOperatorEquals, Value: JSONValue(`"x"`), Action: KnockoutActionReject}
+		assert.Error(t, rule.Validate(&JobQuestion{Type: QuestionTypeText}))
+	})
+}
+
+func TestEvaluateKnockout(t *testing.T) {
+	onsite := JobQuestion{ID: uuid.New(), Type: QuestionTypeYesNo}
+	years := JobQuestion{ID: uuid.New(), Type: QuestionTypeNumber}
+	questions := []JobQuestion{onsite, years}
+
+	flag := KnockoutRule{ID: uuid.New(), QuestionID: onsite.ID, Operator: KnockoutOperatorEquals, Value: JSONValue(`true`), Action: KnockoutActionFlag}
+	reject := KnockoutRule{ID: uuid.New(), QuestionID: years.ID, Operator: KnockoutOperatorGTE, Value: JSONValue(`3`), Action: KnockoutActionReject, Message: "Exige 3 anos de experiência", NoticeDelayHours: 24}
+	rules := []KnockoutRule{flag, reject}
+
+	t.Run("should prefer a rejection over a flag", func(t *testing.T) {
+		fired := EvaluateKnockout(rules, questions, map[uuid.UUID]json.RawMessage{
+			onsite.ID: json.RawMessage(`false`),
+			years.ID:  json.RawMessage(`1`),
+		})
+		assert.Equal(t, reject.ID, fired.ID)
+	})
+
+	t.Run("should flag when only a flag rule fires", func(t *testing.T) {
+		fired := EvaluateKnockout(rules, questions, map[uuid.UUID]json.RawMessage{
+			onsite.ID: json.RawMessage(`false`),
+			years.ID:  json.RawMessage(`5`),
+		})
+		assert.Equal(t, flag.ID, fired.ID)
+	})
+
+	t.Run("should return nil when every criterion is met", func(t *testing.T) {
+		fired := EvaluateKnockout(rules, questions, map[uuid.UUID]json.RawMessage{
+			onsite.ID: json.RawMessage(`true`),
+			years.ID:  json.RawMessage(`5`),
+		})
+		assert.Nil(t, fired)
+	})
+
+	t.Run("should hide a delayed rejection from the candidate", func(t *testing.T) {
+		now := time.Now()
+		app := Application{Status: ApplicationStatusPending}
+		app.ApplyKnockout(&reject, now)
+
+		assert.Equal(t, ApplicationStatusRejected, app.Status)
+		assert.Equal(t, ApplicationStatusPending, app.ToCandidateResponse(now).Status)
+		assert.Empty(t, app.ToCandidateResponse(now).KnockoutReason)
+
+		later := app.ToCandidateResponse(now.Add(25 * time.Hour))
+		assert.Equal(t, ApplicationStatusRejected, later.Status)
+		assert.Equal(t, "Exige 3 anos de experiência", later.KnockoutReason)
+		assert.Nil(t, later.KnockoutRuleID)
+	})
+}
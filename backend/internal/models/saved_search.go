@@ -0,0 +1,166 @@
+package models
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
+	"gorm.io/gorm"
+)
+
+type AlertFrequency string
+
+const (
+	AlertFrequencyInstant AlertFrequency = "instant"
+	AlertFrequencyDaily   AlertFrequency = "daily"
+	AlertFrequencyWeekly  AlertFrequency = "weekly"
+)
+
+// alertIntervals is the minimum time between two alerts of each frequency.
+// Instant alerts go out on every scheduler run that finds new jobs.
+var alertIntervals = map[AlertFrequency]time.Duration{
+	AlertFrequencyInstant: 0,
+	AlertFrequencyDaily:   24 * time.Hour,
+	AlertFrequencyWeekly:  7 * 24 * time.Hour,
+}
+
+// ErrUnsupportedLocation is returned for a radius search around a place the
+// gazetteer does not know: the search is well formed but cannot be run.
+var ErrUnsupportedLocation = errors.New("radius search is not supported for this location")
+
+func ValidAlertFrequency(f AlertFrequency) bool {
+	_, ok := alertIntervals[f]
+	return ok
+}
+
+// AlertFrequencies returns every frequency with its interval.
+func AlertFrequencies() map[AlertFrequency]time.Duration {
+	intervals := make(map[AlertFrequency]time.Duration, len(alertIntervals))
+	for f, d := range alertIntervals {
+		intervals[f] = d
+	}
+	return intervals
+}
+
+// SearchCriteria is a job search as accepted by GET /api/jobs, stored as a
+// jsonb document so saved searches replay exactly what the candidate ran.
+type SearchCriteria struct {
+	Search         string       `json:"search,omitempty"`
+	Location       string       `json:"location,omitempty"`
+	Type           JobType      `json:"type,omitempty" binding:"omitempty,oneof=remote onsite hybrid"`
+	SalaryMin      *float64     `json:"salary_min,omitempty" binding:"omitempty,gte=0"`
+	SalaryMax      *float64     `json:"salary_max,omitempty" binding:"omitempty,gte=0"`
+	SalaryPeriod   SalaryPeriod `json:"salary_period,omitempty"`
+	SalaryCurrency string       `json:"salary_currency,omitempty" binding:"omitempty,iso4217"`
+	Skills         []string     `json:"skills,omitempty"`
+	SkillsMatch    string       `json:"skills_match,omitempty"`
+	Near           string       `json:"near,omitempty"`
+	RadiusKm       float64      `json:"radius_km,omitempty"`
+}
+
+func (c SearchCriteria) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *SearchCriteria) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+// Validate checks the criteria that cannot be expressed as binding tags.
+func (c *SearchCriteria) Validate() error {
+	if c.SalaryPeriod != "" && !ValidSalaryPeriod(c.SalaryPeriod) {
+		return ErrInvalidSalaryPeriod
+	}
+	if c.SkillsMatch != "" && c.SkillsMatch != "any" && c.SkillsMatch != "all" {
+		return errors.New("skills_match must be one of any, all")
+	}
+	if c.RadiusKm < 0 || c.RadiusKm > geo.MaxRadiusKm {
+		return fmt.Errorf("radius_km must be between 0 and %d", geo.MaxRadiusKm)
+	}
+	if c.Near != "" {
+		if _, ok := geo.Lookup(c.Near); !ok {
+			return ErrUnsupportedLocation
+		}
+	}
+	return nil
+}
+
+// SavedSearch is a candidate's named job search. Jobs published after
+// LastCheckedAt that match Criteria are emailed according to Frequency
+// while alerts are enabled.
+type SavedSearch struct {
+	ID               uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CandidateID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"candidate_id"`
+	Name             string         `gorm:"not null" json:"name"`
+	Criteria         SearchCriteria `gorm:"type:jsonb;not null" json:"criteria"`
+	Frequency        AlertFrequency `gorm:"type:varchar(10);not null;default:'daily'" json:"frequency"`
+	AlertsEnabled    bool           `gorm:"not null;default:true" json:"alerts_enabled"`
+	UnsubscribeToken string         `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	LastCheckedAt    time.Time      `gorm:"not null;index" json:"last_checked_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Candidate User `gorm:"foreignKey:CandidateID" json:"-"`
+}
+
+type SavedSearchResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	Name          string         `json:"name"`
+	Criteria      SearchCriteria `json:"criteria"`
+	Frequency     AlertFrequency `json:"frequency"`
+	AlertsEnabled bool           `json:"alerts_enabled"`
+	LastCheckedAt time.Time      `json:"last_checked_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+func (s *SavedSearch) ToResponse() SavedSearchResponse {
+	return SavedSearchResponse{
+		ID:            s.ID,
+		Name:          s.Name,
+		Criteria:      s.Criteria,
+		Frequency:     s.Frequency,
+		AlertsEnabled: s.AlertsEnabled,
+		LastCheckedAt: s.LastCheckedAt,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
+
+// IsDue reports whether the search should be checked for new jobs at now.
+func (s *SavedSearch) IsDue(now time.Time) bool {
+	if !s.AlertsEnabled {
+		return false
+	}
+	return !now.Before(s.LastCheckedAt.Add(alertIntervals[s.Frequency]))
+}
+
+func (s *SavedSearch) BeforeCreate(tx *gorm.DB) error {
+	if s.UnsubscribeToken == "" {
+		token, err := newUnsubscribeToken()
+		if err != nil {
+			return err
+		}
+		s.UnsubscribeToken = token
+	}
+	return nil
+}
+
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
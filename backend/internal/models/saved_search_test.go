@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearch_IsDue(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should always be due for instant alerts", func(t *testing.T) {
+		search := SavedSearch{Frequency: AlertFrequencyInstant, AlertsEnabled: true, LastCheckedAt: now}
+
+		assert.True(t, search.IsDue(now))
+	})
+
+	t.Run("should wait a day between daily alerts", func(t *testing.T) {
+		search := SavedSearch{Frequency: AlertFrequencyDaily, AlertsEnabled: true, LastCheckedAt: now.Add(-23 * time.Hour)}
+		assert.False(t, search.IsDue(now))
+
+		search.LastCheckedAt = now.Add(-24 * time.Hour)
+		assert.True(t, search.IsDue(now))
+	})
+
+	t.Run("should wait a week between weekly alerts", func(t *testing.T) {
+		search := SavedSearch{Frequency: AlertFrequencyWeekly, AlertsEnabled: true, LastCheckedAt: now.AddDate(0, 0, -6)}
+		assert.False(t, search.IsDue(now))
+
+		search.LastCheckedAt = now.AddDate(0, 0, -7)
+		assert.True(t, search.IsDue(now))
+	})
+
+	t.Run("should never be due with alerts disabled", func(t *testing.T) {
+		search := SavedSearch{Frequency: AlertFrequencyInstant, LastCheckedAt: now.AddDate(0, -1, 0)}
+
+		assert.False(t, search.IsDue(now))
+	})
+}
+
+func TestSearchCriteria_Validate(t *testing.T) {
+	t.Run("should accept empty criteria", func(t *testing.T) {
+		assert.NoError(t, (&SearchCriteria{}).Validate())
+	})
+
+	t.Run("should accept a known near location", func(t *testing.T) {
+		criteria := SearchCriteria{Near: "Niterói, RJ", RadiusKm: 30}
+
+		assert.NoError(t, criteria.Validate())
+	})
+
+	t.Run("should reject an unknown near location", func(t *testing.T) {
+		criteria := SearchCriteria{Near: "Cidade Inexistente"}
+
+		assert.ErrorIs(t, criteria.Validate(), ErrUnsupportedLocation)
+	})
+
+	t.Run("should reject invalid salary period, skills match and radius", func(t *testing.T) {
+		assert.ErrorIs(t, (&SearchCriteria{SalaryPeriod: "week"}).Validate(), ErrInvalidSalaryPeriod)
+		assert.Error(t, (&SearchCriteria{SkillsMatch: "some"}).Validate())
+		assert.Error(t, (&SearchCriteria{RadiusKm: 5000}).Validate())
+	})
+
+	t.Run("should round-trip through its column value", func(t *testing.T) {
+		salary := 8000.0
+		criteria := SearchCriteria{Search: "golang", Type: JobTypeRemote, SalaryMin: &salary, Skills: []string{"Go", "SQL"}}
+
+		value, err := criteria.Value()
+		assert.NoError(t, err)
+
+		var scanned SearchCriteria
+		assert.NoError(t, scanned.Scan(value))
+		assert.Equal(t, criteria, scanned)
+	})
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type SkillLevel string
+
+const (
+	SkillLevelRequired   SkillLevel = "required"
+	SkillLevelNiceToHave SkillLevel = "nice_to_have"
+)
+
+// Skill is an entry of the skills taxonomy. Key is the normalized name used
+// for lookups; aliases map alternative spellings ("golang") to the skill.
+type Skill struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	Key       string    `gorm:"uniqueIndex;not null" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Aliases []SkillAlias `gorm:"foreignKey:SkillID" json:"aliases,omitempty"`
+}
+
+type SkillAlias struct {
+	ID      uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SkillID uuid.UUID `gorm:"type:uuid;not null;index" json:"skill_id"`
+	Alias   string    `gorm:"not null" json:"alias"`
+	Key     string    `gorm:"uniqueIndex;not null" json:"-"`
+}
+
+// JobSkill links a job to a skill of the taxonomy.
+type JobSkill struct {
+	JobID   uuid.UUID  `gorm:"type:uuid;primaryKey" json:"job_id"`
+	SkillID uuid.UUID  `gorm:"type:uuid;primaryKey;index" json:"skill_id"`
+	Level   SkillLevel `gorm:"type:varchar(20);not null;default:'required'" json:"level"`
+
+	Skill Skill `gorm:"foreignKey:SkillID" json:"skill,omitempty"`
+}
+
+type SkillResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Aliases  []string  `json:"aliases,omitempty"`
+	OpenJobs int64     `json:"open_jobs"`
+}
+
+type JobSkillResponse struct {
+	ID    uuid.UUID  `json:"id"`
+	Name  string     `json:"name"`
+	Level SkillLevel `json:"level"`
+}
+
+func (s *Skill) BeforeSave(tx *gorm.DB) error {
+	s.Key = utils.NormalizeKey(s.Name)
+	return nil
+}
+
+func (a *SkillAlias) BeforeSave(tx *gorm.DB) error {
+	a.Key = utils.NormalizeKey(a.Alias)
+	return nil
+}
+
+func (s *Skill) ToResponse(openJobs int64) SkillResponse {
+	resp := SkillResponse{
+		ID:       s.ID,
+		Name:     s.Name,
+		OpenJobs: openJobs,
+	}
+	for _, alias := range s.Aliases {
+		resp.Aliases = append(resp.Aliases, alias.Alias)
+	}
+	return resp
+}
+
+func (js *JobSkill) ToResponse() JobSkillResponse {
+	return JobSkillResponse{
+		ID:    js.SkillID,
+		Name:  js.Skill.Name,
+		Level: js.Level,
+	}
+}
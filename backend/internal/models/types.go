@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a list of strings stored as a jsonb array.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// scanJSON decodes a json/jsonb column into dest.
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, dest)
+	}
+}
+
+// JSONValue is an arbitrary JSON document stored in a jsonb column.
+type JSONValue json.RawMessage
+
+func (v JSONValue) Value() (driver.Value, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	return string(v), nil
+}
+
+func (v *JSONValue) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case nil:
+		*v = nil
+	case []byte:
+		*v = append(JSONValue(nil), data...)
+	case string:
+		*v = JSONValue(data)
+	default:
+		return fmt.Errorf("cannot scan %T into JSONValue", value)
+	}
+	return nil
+}
+
+func (v JSONValue) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+func (v *JSONValue) UnmarshalJSON(data []byte) error {
+	*v = append(JSONValue(nil), data...)
+	return nil
+}
@@ -0,0 +1,209 @@
+// Package recommend ranks jobs by how similar they are to a given job,
+// entirely in-process.
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/markdown"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+)
+
+// Weights of each signal in the final score, which stays within [0, 1].
+const (
+	textWeight     = 0.6
+	typeWeight     = 0.15
+	locationWeight = 0.15
+	salaryWeight   = 0.1
+)
+
+// titleBoost repeats title terms so they count more than description terms.
+const titleBoost = 3
+
+// stopWords are frequent Portuguese and English words that carry no meaning
+// about the job itself.
+var stopWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		a ao aos as com como da das de do dos e em entre na nas no nos o os ou para pela pelas pelo pelos por
+		que se sem sua suas seu seus um uma umas uns voce voces nossa nosso nossas nossos ser ter sao esta
+		the and or of to in for with on at by an be is are our your you we will from as this that
+		vaga vagas job jobs`) {
+		stopWords[w] = true
+	}
+}
+
+// Match is a job with its similarity to the reference job.
+type Match struct {
+	Job   models.Job
+	Score float64
+}
+
+// Similar returns up to limit of candidates ranked by similarity to target,
+// most similar first. The target itself and jobs sharing nothing with it
+// are left out. Term weights (IDF) are computed over target and candidates.
+func Similar(target models.Job, candidates []models.Job, limit int) []Match {
+	docs := make([]map[string]float64, 0, len(candidates)+1)
+	docs = append(docs, termFrequencies(target))
+	for _, job := range candidates {
+		docs = append(docs, termFrequencies(job))
+	}
+
+	idf := inverseDocumentFrequencies(docs)
+	targetVec := weigh(docs[0], idf)
+
+	matches := make([]Match, 0, len(candidates))
+	for i, job := range candidates {
+		if job.ID == target.ID && job.ID != uuid.Nil {
+			continue
+		}
+
+		text := cosine(targetVec, weigh(docs[i+1], idf))
+		score := textWeight*text +
+			typeWeight*typeSimilarity(target, job) +
+			locationWeight*locationSimilarity(target, job) +
+			salaryWeight*salarySimilarity(target, job)
+
+		// Type, location and salary alone make almost every job look alike;
+		// require some shared vocabulary.
+		if text == 0 {
+			continue
+		}
+		matches = append(matches, Match{Job: job, Score: score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// termFrequencies counts the meaningful terms of the job's title and
+// description, normalized by the document length.
+func termFrequencies(job models.Job) map[string]float64 {
+	counts := make(map[string]float64)
+	total := 0.0
+
+	add := func(text string, weight float64) {
+		for _, term := range strings.Fields(utils.NormalizeText(text)) {
+			if len(term) < 2 || stopWords[term] {
+				continue
+			}
+			counts[term] += weight
+			total += weight
+		}
+	}
+	add(job.Title, titleBoost)
+	add(markdown.ToText(job.Description), 1)
+
+	for term := range counts {
+		counts[term] /= total
+	}
+	return counts
+}
+
+// inverseDocumentFrequencies uses smoothed IDF so terms present in every
+// document still weigh a little.
+func inverseDocumentFrequencies(docs []map[string]float64) map[string]float64 {
+	df := make(map[string]int)
+	for _, doc := range docs {
+		for term := range doc {
+			df[term]++
+		}
+	}
+
+	n := float64(len(docs))
+	idf := make(map[string]float64, len(df))
+	for term, count := range df {
+		idf[term] = math.Log((1+n)/(1+float64(count))) + 1
+	}
+	return idf
+}
+
+func weigh(tf, idf map[string]float64) map[string]float64 {
+	vec := make(map[string]float64, len(tf))
+	for term, freq := range tf {
+		vec[term] = freq * idf[term]
+	}
+	return vec
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var dot, normA, normB float64
+	for term, wa := range a {
+		dot += wa * b[term]
+		normA += wa * wa
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func typeSimilarity(a, b models.Job) float64 {
+	switch {
+	case a.Type == b.Type:
+		return 1
+	case a.Type == models.JobTypeHybrid || b.Type == models.JobTypeHybrid:
+		// Hybrid sits between remote and on-site.
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// locationSimilarity matches remote jobs with each other regardless of
+// location, and otherwise prefers the same city, then the same state.
+func locationSimilarity(a, b models.Job) float64 {
+	if a.Type == models.JobTypeRemote && b.Type == models.JobTypeRemote {
+		return 1
+	}
+	if a.City != "" && a.City == b.City && a.State == b.State {
+		return 1
+	}
+	if a.State != "" && a.State == b.State {
+		return 0.5
+	}
+	// Locations outside the gazetteer can still match verbatim.
+	if a.City == "" && b.City == "" {
+		if location := utils.NormalizeText(a.Location); location != "" && location == utils.NormalizeText(b.Location) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// salarySimilarity compares the midpoints of the annualized salary ranges
+// when both jobs disclose them in the same currency.
+func salarySimilarity(a, b models.Job) float64 {
+	midA, okA := annualMidpoint(a)
+	midB, okB := annualMidpoint(b)
+	if !okA || !okB || a.SalaryCurrency != b.SalaryCurrency {
+		return 0
+	}
+	return math.Min(midA, midB) / math.Max(midA, midB)
+}
+
+func annualMidpoint(job models.Job) (float64, bool) {
+	if job.SalaryAnnualMin == nil || job.SalaryAnnualMax == nil {
+		return 0, false
+	}
+	mid := (*job.SalaryAnnualMin + *job.SalaryAnnualMax) / 2
+	return mid, mid > 0
+}
@@ -0,0 +1,82 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func job(title, description string, jobType models.JobType, city, state string, annual float64) models.Job {
+	j := models.Job{
+		ID:             uuid.New(),
+		Title:          title,
+		Description:    description,
+		Type:           jobType,
+		City:           city,
+		State:          state,
+		Location:       city,
+		SalaryCurrency: "BRL",
+	}
+	if annual > 0 {
+		j.SalaryAnnualMin = &annual
+		j.SalaryAnnualMax = &annual
+	}
+	return j
+}
+
+func TestSimilar(t *testing.T) {
+	target := job("Desenvolvedor Backend Go", "APIs em Go com PostgreSQL e Docker", models.JobTypeRemote, "", "", 180000)
+
+	goRemote := job("Engenheiro de Software Go", "Microsserviços em Go, PostgreSQL e Kubernetes", models.JobTypeRemote, "", "", 170000)
+	goOnsite := job("Desenvolvedor Go", "Sistemas em Go e PostgreSQL", models.JobTypeOnsite, "São Paulo", "SP", 90000)
+	designer := job("Designer de Produto", "Figma, pesquisa com usuários e prototipação", models.JobTypeRemote, "", "", 180000)
+
+	t.Run("should rank jobs by shared vocabulary and attributes", func(t *testing.T) {
+		matches := Similar(target, []models.Job{designer, goOnsite, goRemote}, 10)
+
+		if assert.Len(t, matches, 2) {
+			assert.Equal(t, goRemote.ID, matches[0].Job.ID)
+			assert.Equal(t, goOnsite.ID, matches[1].Job.ID)
+			assert.Greater(t, matches[0].Score, matches[1].Score)
+			assert.LessOrEqual(t, matches[0].Score, 1.0)
+		}
+	})
+
+	t.Run("should leave out the target itself", func(t *testing.T) {
+		matches := Similar(target, []models.Job{target, goRemote}, 10)
+
+		assert.Len(t, matches, 1)
+		assert.Equal(t, goRemote.ID, matches[0].Job.ID)
+	})
+
+	t.Run("should honour the limit", func(t *testing.T) {
+		matches := Similar(target, []models.Job{goOnsite, goRemote}, 1)
+
+		assert.Len(t, matches, 1)
+	})
+
+	t.Run("should ignore accents, case and stop words", func(t *testing.T) {
+		a := job("Analista de Dados", "", models.JobTypeHybrid, "", "", 0)
+		b := job("ANÁLISTA DADOS", "", models.JobTypeHybrid, "", "", 0)
+
+		matches := Similar(a, []models.Job{b}, 1)
+
+		if assert.Len(t, matches, 1) {
+			assert.InDelta(t, textWeight+typeWeight, matches[0].Score, 0.001)
+		}
+	})
+}
+
+func TestLocationSimilarity(t *testing.T) {
+	t.Run("should prefer the same city over the same state", func(t *testing.T) {
+		rio := job("", "", models.JobTypeOnsite, "Rio de Janeiro", "RJ", 0)
+		niteroi := job("", "", models.JobTypeOnsite, "Niterói", "RJ", 0)
+		sp := job("", "", models.JobTypeOnsite, "São Paulo", "SP", 0)
+
+		assert.Equal(t, 1.0, locationSimilarity(rio, rio))
+		assert.Equal(t, 0.5, locationSimilarity(rio, niteroi))
+		assert.Equal(t, 0.0, locationSimilarity(rio, sp))
+	})
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ledufranco/recruitment-system/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ApplicationRepository struct {
@@ -22,7 +24,7 @@ func (r *ApplicationRepository) Create(application *models.Application) error {
 
 func (r *ApplicationRepository) FindByID(id uuid.UUID) (*models.Application, error) {
 	var application models.Application
-	err := r.db.Preload("Job").Preload("Candidate").Where("id = ?", id).First(&application).Error
+	err := r.db.Preload("Job").Preload("Candidate").Preload("Answers").Where("id = ?", id).First(&application).Error
 	if err != nil {
 		return nil, err
 	}
@@ -40,15 +42,46 @@ func (r *ApplicationRepository) FindByCandidateID(candidateID uuid.UUID) ([]mode
 
 func (r *ApplicationRepository) FindByJobID(jobID uuid.UUID) ([]models.Application, error) {
 	var applications []models.Application
-	err := r.db.Preload("Candidate").
+	err := r.db.Preload("Candidate").Preload("Answers").
 		Where("job_id = ?", jobID).
 		Order("created_at DESC").
 		Find(&applications).Error
 	return applications, err
 }
 
+// DailyCountsForJob counts the applications to the job per day between the
+// from and to days, inclusive. Days without applications are omitted.
+func (r *ApplicationRepository) DailyCountsForJob(jobID uuid.UUID, from, to time.Time) ([]DailyCount, error) {
+	var counts []DailyCount
+	err := r.db.Model(&models.Application{}).
+		Select("(created_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS count").
+		Where("job_id = ? AND created_at >= ? AND created_at < ?", jobID, from, to.AddDate(0, 0, 1)).
+		Group("day").
+		Order("day").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountsBySource counts applications and approved applications per source
+// channel between the from and to days, inclusive, most applications first.
+// A nil jobID counts the applications to every job.
+func (r *ApplicationRepository) CountsBySource(jobID *uuid.UUID, from, to time.Time) ([]models.SourceCount, error) {
+	query := r.db.Model(&models.Application{}).
+		Select("source_channel AS channel, COUNT(*) AS applications, COUNT(*) FILTER (WHERE status = ?) AS approved", models.ApplicationStatusApproved).
+		Where("created_at >= ? AND created_at < ?", from, to.AddDate(0, 0, 1))
+	if jobID != nil {
+		query = query.Where("job_id = ?", *jobID)
+	}
+
+	var counts []models.SourceCount
+	err := query.Group("source_channel").
+		Order("applications DESC, channel").
+		Scan(&counts).Error
+	return counts, err
+}
+
 func (r *ApplicationRepository) Update(application *models.Application) error {
-	return r.db.Save(application).Error
+	return r.db.Omit(clause.Associations).Save(application).Error
 }
 
 func (r *ApplicationRepository) ExistsForJobAndCandidate(jobID, candidateID uuid.UUID) (bool, error) {
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationRepository_CountsBySource(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should count applications and approvals per channel for a job", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewApplicationRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT source_channel AS channel, COUNT(*) AS applications, COUNT(*) FILTER (WHERE status = $1) AS approved FROM "applications" WHERE (created_at >= $2 AND created_at < $3) AND job_id = $4 AND "applications"."deleted_at" IS NULL GROUP BY "source_channel" ORDER BY applications DESC, channel`)).
+			WithArgs("approved", from, to.AddDate(0, 0, 1), jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"channel", "applications", "approved"}).
+				AddRow("linkedin", 3, 1).
+				AddRow("direct", 1, 0))
+
+		counts, err := repo.CountsBySource(&jobID, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "linkedin", counts[0].Channel)
+		assert.Equal(t, int64(3), counts[0].Applications)
+		assert.Equal(t, int64(1), counts[0].Approved)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should count every job when no job is given", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewApplicationRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE (created_at >= $2 AND created_at < $3) AND "applications"."deleted_at" IS NULL GROUP BY`)).
+			WithArgs("approved", from, to.AddDate(0, 0, 1)).
+			WillReturnRows(sqlmock.NewRows([]string{"channel", "applications", "approved"}))
+
+		counts, err := repo.CountsBySource(nil, from, to)
+
+		assert.NoError(t, err)
+		assert.Empty(t, counts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookmarkRepository struct {
+	db *gorm.DB
+}
+
+func NewBookmarkRepository(db *gorm.DB) *BookmarkRepository {
+	return &BookmarkRepository{db: db}
+}
+
+// Add bookmarks the job for the candidate. Bookmarking twice is a no-op.
+func (r *BookmarkRepository) Add(bookmark *models.Bookmark) error {
+	return r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(bookmark).Error
+}
+
+// Remove deletes the bookmark and reports whether there was one.
+func (r *BookmarkRepository) Remove(candidateID, jobID uuid.UUID) (bool, error) {
+	result := r.db.Where("candidate_id = ? AND job_id = ?", candidateID, jobID).Delete(&models.Bookmark{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindByCandidateID returns the candidate's bookmarks of jobs that still
+// exist, most recent first.
+func (r *BookmarkRepository) FindByCandidateID(candidateID uuid.UUID) ([]models.Bookmark, error) {
+	var bookmarks []models.Bookmark
+	err := r.db.Preload("Job").
+		Joins("JOIN jobs ON jobs.id = bookmarks.job_id AND jobs.deleted_at IS NULL").
+		Where("bookmarks.candidate_id = ?", candidateID).
+		Order("bookmarks.created_at DESC").
+		Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+// BookmarkedJobIDs returns which of jobIDs the candidate has bookmarked.
+func (r *BookmarkRepository) BookmarkedJobIDs(candidateID uuid.UUID, jobIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	bookmarked := make(map[uuid.UUID]bool)
+	if len(jobIDs) == 0 {
+		return bookmarked, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.db.Model(&models.Bookmark{}).
+		Where("candidate_id = ? AND job_id IN ?", candidateID, jobIDs).
+		Pluck("job_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		bookmarked[id] = true
+	}
+	return bookmarked, nil
+}
+
+// FindClosingSoon returns the bookmarks of open jobs that stop taking
+// applications, through their deadline or expiry, within window of now and
+// whose candidate has not been warned yet, with job and candidate loaded.
+func (r *BookmarkRepository) FindClosingSoon(now time.Time, window time.Duration) ([]models.Bookmark, error) {
+	var bookmarks []models.Bookmark
+	err := r.db.Preload("Job").Preload("Candidate").
+		Joins("JOIN jobs ON jobs.id = bookmarks.job_id AND jobs.deleted_at IS NULL").
+		Where("bookmarks.closing_notice_sent_at IS NULL").
+		Where("jobs.status = ? AND "+applicationsCloseAtSQL+" > ? AND "+applicationsCloseAtSQL+" <= ?", models.JobStatusOpen, now, now.Add(window)).
+		Order(applicationsCloseAtSQL + " ASC").
+		Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+// ResetClosingNotices lets the job's bookmarks be warned again, after the
+// time it stops taking applications has changed.
+func (r *BookmarkRepository) ResetClosingNotices(jobID uuid.UUID) error {
+	return r.db.Model(&models.Bookmark{}).
+		Where("job_id = ? AND closing_notice_sent_at IS NOT NULL", jobID).
+		UpdateColumn("closing_notice_sent_at", nil).Error
+}
+
+// MarkClosingNoticeSent records that the candidate was warned about the
+// bookmarked job closing.
+func (r *BookmarkRepository) MarkClosingNoticeSent(candidateID, jobID uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.Bookmark{}).
+		Where("candidate_id = ? AND job_id = ?", candidateID, jobID).
+		UpdateColumn("closing_notice_sent_at", at).Error
+}
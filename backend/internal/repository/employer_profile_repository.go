@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type EmployerProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewEmployerProfileRepository(db *gorm.DB) *EmployerProfileRepository {
+	return &EmployerProfileRepository{db: db}
+}
+
+func (r *EmployerProfileRepository) FindByUserID(userID uuid.UUID) (*models.EmployerProfile, error) {
+	var profile models.EmployerProfile
+	err := r.db.Where("user_id = ?", userID).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *EmployerProfileRepository) FindBySlug(slug string) (*models.EmployerProfile, error) {
+	var profile models.EmployerProfile
+	err := r.db.Where("slug = ?", slug).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// SlugAvailable reports whether the user's profile may use slug.
+func (r *EmployerProfileRepository) SlugAvailable(slug string, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EmployerProfile{}).
+		Where("slug = ? AND user_id <> ?", slug, userID).
+		Count(&count).Error
+	return count == 0, err
+}
+
+// employerProfileSlugIndex is the unique index on employer_profiles.slug.
+const employerProfileSlugIndex = "idx_employer_profiles_slug"
+
+// Save creates the profile or updates the existing one. It returns
+// ErrSlugTaken when another profile claimed the slug first.
+func (r *EmployerProfileRepository) Save(profile *models.EmployerProfile) error {
+	var err error
+	if profile.ID == uuid.Nil {
+		err = r.db.Create(profile).Error
+	} else {
+		err = r.db.Save(profile).Error
+	}
+	if isUniqueViolation(err, employerProfileSlugIndex) {
+		return ErrSlugTaken
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmployerProfileRepository_Save(t *testing.T) {
+	t.Run("should report a slug claimed by another profile", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmployerProfileRepository(db)
+		profile := &models.EmployerProfile{UserID: uuid.New(), Name: "Acme", Slug: "acme"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "employer_profiles"`)).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_employer_profiles_slug"})
+		mock.ExpectRollback()
+
+		err := repo.Save(profile)
+
+		assert.ErrorIs(t, err, ErrSlugTaken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should pass other errors through", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmployerProfileRepository(db)
+		profile := &models.EmployerProfile{UserID: uuid.New(), Name: "Acme", Slug: "acme"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "employer_profiles"`)).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.Save(profile)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
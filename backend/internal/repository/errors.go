@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrSlugTaken is returned when another record claimed the slug first.
+var ErrSlugTaken = errors.New("slug is already taken")
+
+const (
+	pgUniqueViolation = "23505"
+	// Classes of Postgres errors caused by the data itself: bad values (22)
+	// and constraint violations (23).
+	pgDataExceptionClass      = "22"
+	pgIntegrityViolationClass = "23"
+)
+
+// isUniqueViolation reports whether err is Postgres refusing a row that
+// duplicates the unique index named index, as when two requests race for
+// the same value.
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == index
+}
+
+// IsRejected reports whether Postgres refused the statement because of the
+// data it carried, so running it again can never succeed.
+func IsRejected(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || len(pgErr.Code) < 2 {
+		return false
+	}
+	class := pgErr.Code[:2]
+	return class == pgDataExceptionClass || class == pgIntegrityViolationClass
+}
+
+// RejectionReason describes why Postgres refused the data in err, naming
+// the constraint when there is one. It returns false for other errors.
+func RejectionReason(err error) (string, bool) {
+	if !IsRejected(err) {
+		return "", false
+	}
+	var pgErr *pgconn.PgError
+	errors.As(err, &pgErr)
+	if pgErr.ConstraintName != "" {
+		return pgErr.Message + " (" + pgErr.ConstraintName + ")", true
+	}
+	return pgErr.Message, true
+}
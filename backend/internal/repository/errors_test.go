@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Run("should match a wrapped violation of the index", func(t *testing.T) {
+		err := fmt.Errorf("save: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_jobs_slug"})
+
+		assert.True(t, isUniqueViolation(err, "idx_jobs_slug"))
+	})
+
+	t.Run("should not match other indexes or errors", func(t *testing.T) {
+		assert.False(t, isUniqueViolation(&pgconn.PgError{Code: "23505", ConstraintName: "jobs_pkey"}, "idx_jobs_slug"))
+		assert.False(t, isUniqueViolation(&pgconn.PgError{Code: "23503", ConstraintName: "idx_jobs_slug"}, "idx_jobs_slug"))
+		assert.False(t, isUniqueViolation(errors.New("connection refused"), "idx_jobs_slug"))
+		assert.False(t, isUniqueViolation(nil, "idx_jobs_slug"))
+	})
+}
+
+func TestIsRejected(t *testing.T) {
+	t.Run("should match data and constraint errors", func(t *testing.T) {
+		assert.True(t, IsRejected(&pgconn.PgError{Code: "22001"}))
+		assert.True(t, IsRejected(&pgconn.PgError{Code: "22021"}))
+		assert.True(t, IsRejected(&pgconn.PgError{Code: "23503"}))
+	})
+
+	t.Run("should not match transient errors", func(t *testing.T) {
+		assert.False(t, IsRejected(&pgconn.PgError{Code: "40001"}))
+		assert.False(t, IsRejected(&pgconn.PgError{Code: "57P01"}))
+		assert.False(t, IsRejected(errors.New("connection refused")))
+	})
+}
+
+func TestRejectionReason(t *testing.T) {
+	t.Run("should describe the value Postgres refused", func(t *testing.T) {
+		reason, ok := RejectionReason(fmt.Errorf("insert: %w", &pgconn.PgError{
+			Code:    "22001",
+			Message: "value too long for type character varying(255)",
+		}))
+
+		assert.True(t, ok)
+		assert.Equal(t, "value too long for type character varying(255)", reason)
+	})
+
+	t.Run("should name the violated constraint", func(t *testing.T) {
+		reason, ok := RejectionReason(&pgconn.PgError{
+			Code:           "23503",
+			Message:        `insert or update on table "jobs" violates foreign key constraint`,
+			ConstraintName: "fk_jobs_recruiter",
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, `insert or update on table "jobs" violates foreign key constraint (fk_jobs_recruiter)`, reason)
+	})
+
+	t.Run("should not describe transient errors", func(t *testing.T) {
+		_, ok := RejectionReason(&pgconn.PgError{Code: "40001", Message: "could not serialize access"})
+
+		assert.False(t, ok)
+	})
+}
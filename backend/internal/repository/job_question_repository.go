@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type JobQuestionRepository struct {
+	db *gorm.DB
+}
+
+func NewJobQuestionRepository(db *gorm.DB) *JobQuestionRepository {
+	return &JobQuestionRepository{db: db}
+}
+
+func (r *JobQuestionRepository) FindByJobID(jobID uuid.UUID) ([]models.JobQuestion, error) {
+	var questions []models.JobQuestion
+	err := r.db.Where("job_id = ?", jobID).Order("position ASC").Find(&questions).Error
+	return questions, err
+}
+
+// ReplaceForJob soft-deletes the job's current questions, and the knockout
+// rules built on them, and stores the new set. Answers already given keep
+// pointing at the old question rows.
+func (r *JobQuestionRepository) ReplaceForJob(jobID uuid.UUID, questions []models.JobQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&models.KnockoutRule{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("job_id = ?", jobID).Delete(&models.JobQuestion{}).Error; err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		for i := range questions {
+			questions[i].JobID = jobID
+			questions[i].Position = i + 1
+		}
+		return tx.Create(&questions).Error
+	})
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
 	"github.com/ledufranco/recruitment-system/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository struct {
@@ -14,52 +17,246 @@ type JobRepository struct {
 }
 
 type JobFilters struct {
-	Search    string
-	Location  string
-	Type      string
-	SalaryMin *float64
-	SalaryMax *float64
-	Status    string
-	SortBy    string
-	Order     string
-	Page      int
-	Limit     int
+	Search         string
+	Location       string
+	Type           string
+	SalaryMin      *float64
+	SalaryMax      *float64
+	SalaryPeriod   models.SalaryPeriod
+	SalaryCurrency string
+	Status         string
+	RecruiterID    *uuid.UUID
+	SkillIDs       []uuid.UUID
+	SkillsMatch    string
+	Near           *geo.Place
+	RadiusKm       float64
+	VisibleAt      *time.Time
+	// PublishedAfter and PublishedBefore bound published_at, exclusive and
+	// inclusive respectively.
+	PublishedAfter  *time.Time
+	PublishedBefore *time.Time
+	// ClosingAfter and ClosingBefore keep jobs still taking applications at
+	// ClosingAfter that stop, through their deadline or expiry, by
+	// ClosingBefore. Both must be set.
+	ClosingAfter  *time.Time
+	ClosingBefore *time.Time
+	SortBy        string
+	Order         string
+	Page          int
+	Limit         int
 }
 
+var jobSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"title":        "title",
+	"salary":       "salary_annual_min",
+	"published_at": "published_at",
+	"closing":      applicationsCloseAtSQL,
+}
+
+// distanceSQL is the haversine distance in km from the point bound to its
+// three placeholders (latitude, longitude, latitude).
+const distanceSQL = "(6371 * acos(LEAST(1, cos(radians(?)) * cos(radians(latitude)) * cos(radians(longitude) - radians(?)) + sin(radians(?)) * sin(radians(latitude)))))"
+
+// applicationsCloseAtSQL is when a job stops taking applications: the
+// earlier of its application deadline and expiry (LEAST ignores NULLs).
+const applicationsCloseAtSQL = "LEAST(jobs.application_deadline, jobs.expires_at)"
+
 func NewJobRepository(db *gorm.DB) *JobRepository {
 	return &JobRepository{db: db}
 }
 
-func (r *JobRepository) Create(job *models.Job) error {
-	return r.db.Create(job).Error
+// Create stores a new job together with its first revision. editorID is the
+// user making the change, or nil for system changes.
+func (r *JobRepository) Create(job *models.Job, editorID *uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createJob(tx, job); err != nil {
+			return err
+		}
+		_, err := recordJobRevision(tx, job, editorID)
+		return err
+	})
+}
+
+// CreateMany stores the jobs and their first revisions in one transaction,
+// so either every job is created or none is.
+func (r *JobRepository) CreateMany(jobs []*models.Job, editorID *uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, job := range jobs {
+			if err := createJob(tx, job); err != nil {
+				return err
+			}
+			if _, err := recordJobRevision(tx, job, editorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *JobRepository) FindByID(id uuid.UUID) (*models.Job, error) {
 	var job models.Job
-	err := r.db.Preload("Recruiter").Where("id = ?", id).First(&job).Error
+	err := r.db.Preload("Recruiter").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Skills.Skill").
+		Where("id = ?", id).
+		First(&job).Error
 	if err != nil {
 		return nil, err
 	}
 	return &job, nil
 }
 
+// FindBySlug returns the job with the slug, or the job that used it before
+// renaming it; callers tell them apart by comparing the job's current slug.
+func (r *JobRepository) FindBySlug(slug string) (*models.Job, error) {
+	var ids []uuid.UUID
+	if err := r.db.Model(&models.Job{}).Where("slug = ?", slug).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		if err := r.db.Model(&models.JobSlug{}).Where("slug = ?", slug).Pluck("job_id", &ids).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(ids) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.FindByID(ids[0])
+}
+
+// SlugAvailable reports whether the job may use slug: it is not reserved
+// and no other job, trashed ones included, uses it now or used it before.
+func (r *JobRepository) SlugAvailable(slug string, jobID uuid.UUID) (bool, error) {
+	if models.UsedJobSlugs(nil)[slug] {
+		return false, nil
+	}
+	var count int64
+	err := r.db.Raw(`
+		SELECT (SELECT COUNT(*) FROM jobs WHERE slug = ? AND id <> ?) +
+		       (SELECT COUNT(*) FROM job_slugs WHERE slug = ? AND job_id <> ?)
+	`, slug, jobID, slug, jobID).Scan(&count).Error
+	return count == 0, err
+}
+
+// UpdateWithSlug saves the job like Update and renames its slug in the same
+// transaction, keeping the old slug so links to it redirect. Going back to a
+// slug the job used before takes it out of the history. It returns
+// ErrSlugTaken when another job claimed the slug first.
+func (r *JobRepository) UpdateWithSlug(job *models.Job, slug string, editorID *uuid.UUID) error {
+	oldSlug := job.Slug
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if oldSlug != "" {
+			old := models.JobSlug{Slug: oldSlug, JobID: job.ID}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&old).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("slug = ? AND job_id = ?", slug, job.ID).Delete(&models.JobSlug{}).Error; err != nil {
+			return err
+		}
+		job.Slug = slug
+		if err := tx.Omit(clause.Associations).Save(job).Error; err != nil {
+			return err
+		}
+		_, err := recordJobRevision(tx, job, editorID)
+		return err
+	})
+	if err != nil {
+		job.Slug = oldSlug
+		if isUniqueViolation(err, jobSlugIndex) {
+			return ErrSlugTaken
+		}
+	}
+	return err
+}
+
+// jobSlugIndex is the unique index on jobs.slug.
+const jobSlugIndex = "idx_jobs_slug"
+
+// maxSlugAttempts bounds how many slugs createJob tries when concurrent
+// requests keep claiming the one it picked.
+const maxSlugAttempts = 5
+
+// createJob inserts a new job, giving it a slug when it has none. Another
+// request may claim the same slug between picking and inserting it; the
+// insert then skips the row and createJob moves on to the next free suffix.
+func createJob(tx *gorm.DB, job *models.Job) error {
+	if job.Slug != "" {
+		err := tx.Omit(clause.Associations).Create(job).Error
+		if isUniqueViolation(err, jobSlugIndex) {
+			return ErrSlugTaken
+		}
+		return err
+	}
+
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		if err := assignSlug(tx, job); err != nil {
+			return err
+		}
+		result := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).
+			Create(job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		job.Slug = ""
+	}
+	return ErrSlugTaken
+}
+
+// assignSlug gives a new job a slug made from its title that no job uses or
+// used, appending -2, -3 and so on to tell jobs with the same title apart.
+func assignSlug(tx *gorm.DB, job *models.Job) error {
+	if job.Slug != "" {
+		return nil
+	}
+	base := models.JobSlugBase(job.Title)
+
+	var taken []string
+	err := tx.Raw(`
+		SELECT slug FROM jobs WHERE slug = ? OR slug LIKE ?
+		UNION SELECT slug FROM job_slugs WHERE slug = ? OR slug LIKE ?
+	`, base, base+"-%", base, base+"-%").Scan(&taken).Error
+	if err != nil {
+		return err
+	}
+
+	job.Slug = utils.UniqueSlug(base, models.UsedJobSlugs(taken))
+	return nil
+}
+
 func (r *JobRepository) FindAll(filters JobFilters) ([]models.Job, int64, error) {
 	var jobs []models.Job
 	var total int64
 
-	query := r.db.Model(&models.Job{}).Preload("Recruiter")
+	query := r.db.Model(&models.Job{}).Preload("Recruiter").Preload("Skills.Skill")
 
+	// A job matches when its own text contains every word, ignoring accents,
+	// or when the search matches its own text or any of its translations
+	// through full-text search, stemmed in each text's language.
 	if filters.Search != "" {
 		normalized := utils.NormalizeText(filters.Search)
 		words := strings.Fields(normalized)
-		
+
+		textMatch := r.db
 		for _, word := range words {
 			pattern := "%" + word + "%"
-			query = query.Where(
-				"translate(lower(title), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE ? OR translate(lower(description), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE ?",
+			textMatch = textMatch.Where(
+				"translate(lower(title), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE ? OR translate(lower(description_text), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE ?",
 				pattern, pattern,
 			)
 		}
+
+		tsQuery := "search_vector @@ websearch_to_tsquery(" + models.SearchConfigSQL("locale") + ", ?)"
+		translated := r.db.Model(&models.JobTranslation{}).Select("job_id").Where(tsQuery, filters.Search)
+		query = query.Where(textMatch.Or(tsQuery, filters.Search).Or("id IN (?)", translated))
 	}
 
 	if filters.Location != "" {
@@ -72,35 +269,101 @@ func (r *JobRepository) FindAll(filters JobFilters) ([]models.Job, int64, error)
 		query = query.Where("type = ?", filters.Type)
 	}
 
+	// Salary filters are expressed per SalaryPeriod (monthly by default) and
+	// compared against the annualized range so hourly, monthly and yearly
+	// postings can be matched against each other.
+	salaryPeriod := filters.SalaryPeriod
+	if salaryPeriod == "" {
+		salaryPeriod = models.SalaryPeriodMonth
+	}
+
 	if filters.SalaryMin != nil {
-		query = query.Where("salary >= ?", *filters.SalaryMin)
+		query = query.Where("salary_annual_max >= ?", models.AnnualizeSalary(*filters.SalaryMin, salaryPeriod))
 	}
 
 	if filters.SalaryMax != nil {
-		query = query.Where("salary <= ?", *filters.SalaryMax)
+		query = query.Where("salary_annual_min <= ?", models.AnnualizeSalary(*filters.SalaryMax, salaryPeriod))
+	}
+
+	if filters.SalaryCurrency != "" {
+		query = query.Where("salary_currency = ?", strings.ToUpper(filters.SalaryCurrency))
 	}
 
 	if filters.Status != "" {
 		query = query.Where("status = ?", filters.Status)
 	}
 
+	if filters.RecruiterID != nil {
+		query = query.Where("recruiter_id = ?", *filters.RecruiterID)
+	}
+
+	// Radius search only applies to on-site and hybrid jobs; remote jobs are
+	// kept regardless of where the candidate is.
+	if filters.Near != nil && filters.RadiusKm > 0 {
+		lat, lng := filters.Near.Latitude, filters.Near.Longitude
+		minLat, maxLat, minLng, maxLng := geo.BoundingBox(lat, lng, filters.RadiusKm)
+		query = query.Where(
+			"type = ? OR (latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ? AND "+distanceSQL+" <= ?)",
+			models.JobTypeRemote, minLat, maxLat, minLng, maxLng, lat, lng, lat, filters.RadiusKm,
+		)
+	}
+
+	// SkillsMatch "all" keeps jobs linked to every skill; anything else keeps
+	// jobs linked to at least one.
+	if len(filters.SkillIDs) > 0 {
+		skillJobs := r.db.Model(&models.JobSkill{}).Select("job_id").Where("skill_id IN ?", filters.SkillIDs)
+		if filters.SkillsMatch == "all" {
+			skillJobs = skillJobs.Group("job_id").Having("COUNT(DISTINCT skill_id) = ?", len(filters.SkillIDs))
+		}
+		query = query.Where("id IN (?)", skillJobs)
+	}
+
+	// VisibleAt restricts the listing to what the public may see at that
+	// instant, even if the scheduler has not caught up with publish_at or
+	// expires_at yet.
+	if filters.VisibleAt != nil {
+		query = query.Where("status IN ?", models.PublishedJobStatuses).
+			Where("publish_at IS NULL OR publish_at <= ?", *filters.VisibleAt)
+		if filters.Status == string(models.JobStatusOpen) {
+			query = query.Where("expires_at IS NULL OR expires_at > ?", *filters.VisibleAt)
+		}
+	}
+
+	if filters.PublishedAfter != nil {
+		query = query.Where("published_at > ?", *filters.PublishedAfter)
+	}
+	if filters.PublishedBefore != nil {
+		query = query.Where("published_at <= ?", *filters.PublishedBefore)
+	}
+
+	if filters.ClosingAfter != nil && filters.ClosingBefore != nil {
+		query = query.Where(applicationsCloseAtSQL+" > ? AND "+applicationsCloseAtSQL+" <= ?", *filters.ClosingAfter, *filters.ClosingBefore)
+	}
+
 	
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	
-	sortBy := "created_at"
-	if filters.SortBy != "" {
-		sortBy = filters.SortBy
+	sortBy, ok := jobSortColumns[filters.SortBy]
+	if !ok {
+		sortBy = "created_at"
 	}
 
 	order := "DESC"
-	if filters.Order != "" {
-		order = filters.Order
+	if strings.EqualFold(filters.Order, "ASC") {
+		order = "ASC"
 	}
 
-	query = query.Order(sortBy + " " + order)
+	if filters.SortBy == "distance" && filters.Near != nil {
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "CASE WHEN type = ? THEN NULL ELSE " + distanceSQL + " END " + order + " NULLS LAST",
+			Vars: []interface{}{models.JobTypeRemote, filters.Near.Latitude, filters.Near.Longitude, filters.Near.Latitude},
+		}})
+	} else {
+		query = query.Order(sortBy + " " + order + " NULLS LAST")
+	}
 
 	
 	limit := 20
@@ -129,10 +392,402 @@ func (r *JobRepository) FindByRecruiterID(recruiterID uuid.UUID) ([]models.Job,
 	return jobs, err
 }
 
-func (r *JobRepository) Update(job *models.Job) error {
-	return r.db.Save(job).Error
+// FindByTeamMember returns the jobs the user created or is on the hiring
+// team of.
+func (r *JobRepository) FindByTeamMember(userID uuid.UUID) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("recruiter_id = ?", userID).
+		Or("id IN (?)", r.db.Model(&models.JobTeamMember{}).Select("job_id").Where("user_id = ?", userID)).
+		Order("created_at DESC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Update saves the job and records a revision with the fields that changed.
+func (r *JobRepository) Update(job *models.Job, editorID *uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(job).Error; err != nil {
+			return err
+		}
+		_, err := recordJobRevision(tx, job, editorID)
+		return err
+	})
+}
+
+// RecordReview saves the job's new status and the review that decided it in
+// one transaction, so neither is stored without the other.
+func (r *JobRepository) RecordReview(job *models.Job, review *models.JobReview) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(job).Error; err != nil {
+			return err
+		}
+		if _, err := recordJobRevision(tx, job, &review.ReviewerID); err != nil {
+			return err
+		}
+		return tx.Create(review).Error
+	})
+}
+
+// SyncOpenings recounts the job's approved applications into Filled. When
+// an open job becomes filled it is closed and, if the job asks for it, the
+// applications still pending are rejected with the job's fill message (or
+// defaultMessage). It returns the updated job and the number of
+// applications rejected.
+func (r *JobRepository) SyncOpenings(jobID uuid.UUID, defaultMessage string, editorID *uuid.UUID) (*models.Job, int64, error) {
+	return r.syncOpenings(jobID, defaultMessage, editorID, nil)
+}
+
+// SaveApplicationAndSyncOpenings saves an application whose status change
+// affects its job's openings and runs SyncOpenings in the same transaction,
+// so the count can never miss the change.
+func (r *JobRepository) SaveApplicationAndSyncOpenings(application *models.Application, defaultMessage string, editorID *uuid.UUID) (*models.Job, int64, error) {
+	return r.syncOpenings(application.JobID, defaultMessage, editorID, func(tx *gorm.DB) error {
+		return tx.Omit(clause.Associations).Save(application).Error
+	})
+}
+
+// syncOpenings implements SyncOpenings, running change, when given, once
+// the job is locked and before the approved applications are counted.
+func (r *JobRepository) syncOpenings(jobID uuid.UUID, defaultMessage string, editorID *uuid.UUID, change func(tx *gorm.DB) error) (*models.Job, int64, error) {
+	var job models.Job
+	var rejected int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", jobID).First(&job).Error; err != nil {
+			return err
+		}
+		if change != nil {
+			if err := change(tx); err != nil {
+				return err
+			}
+		}
+
+		var filled int64
+		err := tx.Model(&models.Application{}).
+			Where("job_id = ? AND status = ?", jobID, models.ApplicationStatusApproved).
+			Count(&filled).Error
+		if err != nil {
+			return err
+		}
+		job.Filled = int(filled)
+
+		if !job.IsFilled() || job.Status != models.JobStatusOpen {
+			return tx.Model(&job).UpdateColumn("filled", job.Filled).Error
+		}
+
+		job.Status = models.JobStatusClosed
+		if err := tx.Omit(clause.Associations).Save(&job).Error; err != nil {
+			return err
+		}
+		if _, err := recordJobRevision(tx, &job, editorID); err != nil {
+			return err
+		}
+
+		if !job.AutoRejectOnFill {
+			return nil
+		}
+		message := job.FillMessage
+		if message == "" {
+			message = defaultMessage
+		}
+		result := tx.Model(&models.Application{}).
+			Where("job_id = ? AND status = ?", jobID, models.ApplicationStatusPending).
+			Updates(map[string]interface{}{
+				"status":         models.ApplicationStatusRejected,
+				"status_message": message,
+			})
+		rejected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &job, rejected, nil
+}
+
+// SetSkills replaces the skills linked to the job.
+func (r *JobRepository) SetSkills(jobID uuid.UUID, skills []models.JobSkill) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&models.JobSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		for i := range skills {
+			skills[i].JobID = jobID
+		}
+		return tx.Omit("Skill").Create(&skills).Error
+	})
+}
+
+// CurrentRevision returns the latest revision of the job, recording one from
+// its current state if the job predates revision history.
+func (r *JobRepository) CurrentRevision(job *models.Job) (*models.JobRevision, error) {
+	var revision *models.JobRevision
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		revision, err = recordJobRevision(tx, job, nil)
+		return err
+	})
+	return revision, err
+}
+
+func (r *JobRepository) FindRevisions(jobID uuid.UUID) ([]models.JobRevision, error) {
+	var revisions []models.JobRevision
+	err := r.db.Preload("Editor").
+		Where("job_id = ?", jobID).
+		Order("revision DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *JobRepository) FindRevision(jobID uuid.UUID, revision int) (*models.JobRevision, error) {
+	var rev models.JobRevision
+	err := r.db.Where("job_id = ? AND revision = ?", jobID, revision).First(&rev).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
 }
 
+// recordJobRevision appends a revision when the job differs from its latest
+// revision and returns the revision now current. A nil editorID records a
+// system revision.
+func recordJobRevision(tx *gorm.DB, job *models.Job, editorID *uuid.UUID) (*models.JobRevision, error) {
+	// Locking the job serializes concurrent edits, including the first
+	// revision of a job, which has no revision rows to lock yet.
+	var locked models.Job
+	err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", job.ID).
+		Take(&locked).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var latest models.JobRevision
+	err = tx.Where("job_id = ?", job.ID).
+		Order("revision DESC").
+		Limit(1).
+		Find(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := job.Snapshot()
+	changed := models.ChangedFields(latest.Snapshot, snapshot)
+	if latest.ID != uuid.Nil && len(changed) == 0 {
+		return &latest, nil
+	}
+
+	revision := &models.JobRevision{
+		JobID:         job.ID,
+		Revision:      latest.Revision + 1,
+		EditorID:      editorID,
+		System:        editorID == nil,
+		ChangedFields: changed,
+		Snapshot:      snapshot,
+	}
+	if err := tx.Create(revision).Error; err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// PublishDue opens scheduled jobs whose publish_at has been reached.
+func (r *JobRepository) PublishDue(now time.Time) (int64, error) {
+	return r.applyScheduleWhere(now, "status = ? AND publish_at <= ?", models.JobStatusScheduled, now)
+}
+
+// CloseExpired closes open jobs whose expires_at has passed.
+func (r *JobRepository) CloseExpired(now time.Time) (int64, error) {
+	return r.applyScheduleWhere(now, "status = ? AND expires_at <= ?", models.JobStatusOpen, now)
+}
+
+// applyScheduleWhere runs Job.ApplySchedule on the matching jobs and records
+// each transition as a system change. The status is only written while the
+// job still matches the query, so an edit made since it was read is kept.
+func (r *JobRepository) applyScheduleWhere(now time.Time, query string, args ...interface{}) (int64, error) {
+	var jobs []models.Job
+	if err := r.db.Where(query, args...).Find(&jobs).Error; err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	for i := range jobs {
+		job := &jobs[i]
+		job.ApplySchedule(now)
+		changes := map[string]interface{}{"status": job.Status}
+		if job.Status == models.JobStatusOpen {
+			// A single-column update would drop the published_at stamp
+			// BeforeSave only sets on the struct.
+			changes["published_at"] = gorm.Expr("COALESCE(published_at, ?)", now)
+		}
+		applied := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(job).
+				Clauses(clause.Returning{}).
+				Where(query, args...).
+				Updates(changes)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			applied = true
+			_, err := recordJobRevision(tx, job, nil)
+			return err
+		})
+		if err != nil {
+			return updated, err
+		}
+		if applied {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// Delete moves the job to the trash together with its applications. Both
+// get the same deleted_at, which is how Restore tells them apart from
+// applications withdrawn before the job was deleted.
 func (r *JobRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Job{}, "id = ?", id).Error
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Application{}).
+			Where("job_id = ?", id).
+			UpdateColumn("deleted_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Job{}).
+			Where("id = ?", id).
+			UpdateColumn("deleted_at", now).Error
+	})
+}
+
+// FindDeletedByID returns a job in the trash.
+func (r *JobRepository) FindDeletedByID(id uuid.UUID) (*models.Job, error) {
+	var job models.Job
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindDeletedByTeamMember returns the trashed jobs the user created or is on
+// the hiring team of, most recently deleted first.
+func (r *JobRepository) FindDeletedByTeamMember(userID uuid.UUID) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where(r.db.Where("recruiter_id = ?", userID).
+			Or("id IN (?)", r.db.Model(&models.JobTeamMember{}).Select("job_id").Where("user_id = ?", userID))).
+		Order("deleted_at DESC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// TrashedApplicationCounts returns how many applications were trashed along
+// with each of the jobs.
+func (r *JobRepository) TrashedApplicationCounts(jobIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64)
+	if len(jobIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		JobID uuid.UUID
+		Count int64
+	}
+	err := r.db.Unscoped().Model(&models.Application{}).
+		Select("applications.job_id, COUNT(*) AS count").
+		Joins("JOIN jobs ON jobs.id = applications.job_id AND applications.deleted_at = jobs.deleted_at").
+		Where("applications.job_id IN ?", jobIDs).
+		Group("applications.job_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.JobID] = row.Count
+	}
+	return counts, nil
+}
+
+// Restore takes the job out of the trash with the applications that were
+// trashed along with it.
+func (r *JobRepository) Restore(job *models.Job) error {
+	deletedAt := job.DeletedAt.Time
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Application{}).
+			Where("job_id = ? AND deleted_at = ?", job.ID, deletedAt).
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Model(&models.Job{}).
+			Where("id = ?", job.ID).
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		job.DeletedAt = gorm.DeletedAt{}
+		return nil
+	})
+}
+
+// PurgeDeleted permanently deletes the jobs trashed before cutoff with
+// everything that belongs to them, and returns how many were purged.
+func (r *JobRepository) PurgeDeleted(cutoff time.Time) (int64, error) {
+	var ids []uuid.UUID
+	err := r.db.Unscoped().Model(&models.Job{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// Every statement starts from a fresh session, or the conditions of
+		// one delete would carry over to the next.
+		purge := func() *gorm.DB {
+			return tx.Session(&gorm.Session{NewDB: true}).Unscoped()
+		}
+
+		applications := purge().Model(&models.Application{}).Select("id").Where("job_id IN ?", ids)
+		if err := purge().Where("application_id IN (?)", applications).Delete(&models.ApplicationAnswer{}).Error; err != nil {
+			return err
+		}
+		for _, dependent := range []interface{}{
+			&models.Application{},
+			&models.KnockoutRule{},
+			&models.JobQuestion{},
+			&models.JobSkill{},
+			&models.JobTeamMember{},
+			&models.JobReview{},
+			&models.Bookmark{},
+			&models.JobView{},
+			&models.JobTranslation{},
+			&models.JobSlug{},
+		} {
+			if err := purge().Where("job_id IN ?", ids).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		// Revisions refuse to be deleted; purging the job is the one
+		// exception, so their hooks are skipped.
+		revisions := purge().Session(&gorm.Session{SkipHooks: true})
+		if err := revisions.Where("job_id IN ?", ids).Delete(&models.JobRevision{}).Error; err != nil {
+			return err
+		}
+		return purge().Where("id IN ?", ids).Delete(&models.Job{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
 }
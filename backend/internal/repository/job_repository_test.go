@@ -0,0 +1,651 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestJobRepository_FindAll_SalaryFilters(t *testing.T) {
+	t.Run("should compare monthly salary filters against annualized range", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		salaryMin := 8000.0
+		salaryMax := 12000.0
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE salary_annual_max >= $1 AND salary_annual_min <= $2`)).
+			WithArgs(96000.0, 144000.0).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE salary_annual_max >= $1 AND salary_annual_min <= $2`)).
+			WithArgs(96000.0, 144000.0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		jobs, total, err := repo.FindAll(JobFilters{SalaryMin: &salaryMin, SalaryMax: &salaryMax})
+
+		assert.NoError(t, err)
+		assert.Empty(t, jobs)
+		assert.Equal(t, int64(0), total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should annualize hourly salary filters", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		salaryMin := 50.0
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE salary_annual_max >= $1 AND salary_currency = $2`)).
+			WithArgs(50.0*models.HoursPerYear, "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{
+			SalaryMin:      &salaryMin,
+			SalaryPeriod:   models.SalaryPeriodHour,
+			SalaryCurrency: "usd",
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should ignore unknown sort columns", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY created_at DESC NULLS LAST`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{SortBy: "id; DROP TABLE jobs", Order: "sideways"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_FindAll_SkillFilters(t *testing.T) {
+	goID, sqlID := uuid.New(), uuid.New()
+
+	t.Run("should match jobs with any of the skills", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE id IN (SELECT "job_id" FROM "job_skills" WHERE skill_id IN ($1,$2))`)).
+			WithArgs(goID, sqlID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{SkillIDs: []uuid.UUID{goID, sqlID}, SkillsMatch: "any"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should require every skill when matching all", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE id IN (SELECT "job_id" FROM "job_skills" WHERE skill_id IN ($1,$2) GROUP BY "job_id" HAVING COUNT(DISTINCT skill_id) = $3)`)).
+			WithArgs(goID, sqlID, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{SkillIDs: []uuid.UUID{goID, sqlID}, SkillsMatch: "all"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_FindAll_Radius(t *testing.T) {
+	rio, _ := geo.Lookup("Rio de Janeiro, RJ")
+
+	t.Run("should keep remote jobs and bound the rest by distance", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE (type = $1 OR (latitude BETWEEN $2 AND $3 AND longitude BETWEEN $4 AND $5 AND (6371 * acos(`)).
+			WithArgs(models.JobTypeRemote, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				rio.Latitude, rio.Longitude, rio.Latitude, 30.0).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY CASE WHEN type = $10 THEN NULL ELSE (6371 * acos(`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{Near: &rio, RadiusKm: 30, SortBy: "distance", Order: "ASC"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_FindAll_PublishedWindow(t *testing.T) {
+	t.Run("should bound published_at and sort by publication", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		after := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+		before := after.Add(24 * time.Hour)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" WHERE published_at > $1 AND published_at <= $2`)).
+			WithArgs(after, before).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY published_at DESC NULLS LAST`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.FindAll(JobFilters{PublishedAfter: &after, PublishedBefore: &before, SortBy: "published_at"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_CreateMany(t *testing.T) {
+	t.Run("should roll back the whole batch when a job fails", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobs := []*models.Job{
+			{Title: "Backend Developer", Status: models.JobStatusDraft},
+			{Title: "Frontend Developer", Status: models.JobStatusDraft},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "jobs"`)).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.CreateMany(jobs, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_AssignSlug(t *testing.T) {
+	t.Run("should number the slug when the title is taken", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		job := &models.Job{Title: "Desenvolvedor Back-end"}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs WHERE slug = $1 OR slug LIKE $2`)).
+			WithArgs("desenvolvedor-back-end", "desenvolvedor-back-end-%", "desenvolvedor-back-end", "desenvolvedor-back-end-%").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).
+				AddRow("desenvolvedor-back-end").
+				AddRow("desenvolvedor-back-end-2"))
+
+		err := assignSlug(db, job)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "desenvolvedor-back-end-3", job.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should keep a slug already set", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		job := &models.Job{Title: "Backend", Slug: "backend-go"}
+
+		assert.NoError(t, assignSlug(db, job))
+		assert.Equal(t, "backend-go", job.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_CreateJob(t *testing.T) {
+	t.Run("should move on to the next slug when another job claims it first", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		job := &models.Job{Title: "Backend", Status: models.JobStatusDraft}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT ("slug") DO NOTHING`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("backend"))
+		mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT ("slug") DO NOTHING`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		err := db.Transaction(func(tx *gorm.DB) error { return createJob(tx, job) })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend-2", job.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should give up after repeated conflicts", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		job := &models.Job{Title: "Backend", Status: models.JobStatusDraft}
+
+		mock.ExpectBegin()
+		for i := 0; i < maxSlugAttempts; i++ {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT slug FROM jobs`)).
+				WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT ("slug") DO NOTHING`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		}
+		mock.ExpectRollback()
+
+		err := db.Transaction(func(tx *gorm.DB) error { return createJob(tx, job) })
+
+		assert.ErrorIs(t, err, ErrSlugTaken)
+		assert.Empty(t, job.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_UpdateWithSlug(t *testing.T) {
+	t.Run("should report a slug claimed by another job and roll back", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		job := &models.Job{ID: uuid.New(), Title: "Backend", Slug: "backend", Status: models.JobStatusDraft}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "job_slugs" ("slug","job_id","created_at") VALUES ($1,$2,$3) ON CONFLICT DO NOTHING`)).
+			WithArgs("backend", job.ID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "job_slugs" WHERE slug = $1 AND job_id = $2`)).
+			WithArgs("backend-go", job.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_jobs_slug"})
+		mock.ExpectRollback()
+
+		err := repo.UpdateWithSlug(job, "backend-go", nil)
+
+		assert.ErrorIs(t, err, ErrSlugTaken)
+		assert.Equal(t, "backend", job.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_Delete(t *testing.T) {
+	t.Run("should trash the job and its live applications together", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET "deleted_at"=$1 WHERE job_id = $2 AND "applications"."deleted_at" IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), jobID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET "deleted_at"=$1 WHERE id = $2 AND "jobs"."deleted_at" IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Delete(jobID)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_PurgeDeleted(t *testing.T) {
+	t.Run("should delete the trashed jobs and everything that belongs to them", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		cutoff := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		jobID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE deleted_at IS NOT NULL AND deleted_at < $1`)).
+			WithArgs(cutoff).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(jobID))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "application_answers" WHERE application_id IN (SELECT "id" FROM "applications" WHERE job_id IN ($1))`)).
+			WithArgs(jobID).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		for _, table := range []string{
+			"applications",
+			"knockout_rules",
+			"job_questions",
+			"job_skills",
+			"job_team_members",
+			"job_reviews",
+			"bookmarks",
+			"job_views",
+			"job_translations",
+			"job_slugs",
+			"job_revisions",
+		} {
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "`+table+`" WHERE job_id IN ($1)`) + `$`).
+				WithArgs(jobID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "jobs" WHERE id IN ($1)`) + `$`).
+			WithArgs(jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeDeleted(cutoff)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not open a transaction when nothing is due", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		purged, err := repo.PurgeDeleted(time.Now())
+
+		assert.NoError(t, err)
+		assert.Zero(t, purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_Restore(t *testing.T) {
+	t.Run("should restore only the applications trashed with the job", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		deletedAt := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+		job := &models.Job{ID: uuid.New(), DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET "deleted_at"=$1 WHERE job_id = $2 AND deleted_at = $3`)).
+			WithArgs(nil, job.ID, deletedAt).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET "deleted_at"=$1 WHERE id = $2`)).
+			WithArgs(nil, job.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Restore(job)
+
+		assert.NoError(t, err)
+		assert.False(t, job.DeletedAt.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_PublishDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	publishAt := now.Add(-time.Minute)
+	dueRows := func(jobIDs ...uuid.UUID) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "status", "publish_at"})
+		for _, id := range jobIDs {
+			rows.AddRow(id, models.JobStatusScheduled, publishAt)
+		}
+		return rows
+	}
+
+	t.Run("should open the job only while it is still due and record a system revision", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE (status = $1 AND publish_at <= $2)`)).
+			WithArgs(models.JobStatusScheduled, now).
+			WillReturnRows(dueRows(jobID))
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "jobs" SET "published_at"=COALESCE(published_at, $1),"status"=$2,"updated_at"=$3 WHERE (status = $4 AND publish_at <= $5) AND "jobs"."deleted_at" IS NULL AND "id" = $6 RETURNING *`)).
+			WithArgs(now, models.JobStatusOpen, sqlmock.AnyArg(), models.JobStatusScheduled, now, jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "publish_at", "published_at"}).AddRow(jobID, models.JobStatusOpen, publishAt, now))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE id = $1 LIMIT 1 FOR UPDATE`)).
+			WithArgs(jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(jobID))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_revisions" WHERE job_id = $1 ORDER BY revision DESC LIMIT 1`)).
+			WithArgs(jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_revisions" ("job_id","revision","editor_id","system","changed_fields","snapshot","created_at")`)).
+			WithArgs(jobID, 1, nil, true, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		published, err := repo.PublishDue(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), published)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should skip a job changed since it was read", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs"`)).
+			WillReturnRows(dueRows(jobID))
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "jobs" SET "published_at"=COALESCE(published_at, $1),"status"=$2`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}))
+		mock.ExpectCommit()
+
+		published, err := repo.PublishDue(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, published)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_SyncOpenings(t *testing.T) {
+	jobRows := func(jobID uuid.UUID, openings int, autoReject bool, fillMessage string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "status", "openings", "filled", "auto_reject_on_fill", "fill_message"}).
+			AddRow(jobID, models.JobStatusOpen, openings, 0, autoReject, fillMessage)
+	}
+	expectLockAndCount := func(mock sqlmock.Sqlmock, jobID uuid.UUID, rows *sqlmock.Rows, approved int) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1 AND "jobs"."deleted_at" IS NULL ORDER BY "jobs"."id" LIMIT 1 FOR UPDATE`)).
+			WithArgs(jobID).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "applications" WHERE (job_id = $1 AND status = $2)`)).
+			WithArgs(jobID, models.ApplicationStatusApproved).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(approved))
+	}
+
+	t.Run("should only store the count while openings remain", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectBegin()
+		expectLockAndCount(mock, jobID, jobRows(jobID, 3, true, ""), 2)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET "filled"=$1 WHERE "jobs"."deleted_at" IS NULL AND "id" = $2`)).
+			WithArgs(2, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		job, rejected, err := repo.SyncOpenings(jobID, "Vagas preenchidas", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.JobStatusOpen, job.Status)
+		assert.Equal(t, 2, job.Filled)
+		assert.Zero(t, rejected)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should close a filled job and reject its pending applications", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectBegin()
+		expectLockAndCount(mock, jobID, jobRows(jobID, 2, true, ""), 2)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE id = $1 LIMIT 1 FOR UPDATE`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(jobID))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_revisions"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_revisions"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET "status"=$1,"status_message"=$2,"updated_at"=$3 WHERE (job_id = $4 AND status = $5)`)).
+			WithArgs(models.ApplicationStatusRejected, "Vagas preenchidas", sqlmock.AnyArg(), jobID, models.ApplicationStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectCommit()
+
+		job, rejected, err := repo.SyncOpenings(jobID, "Vagas preenchidas", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.JobStatusClosed, job.Status)
+		assert.Equal(t, int64(4), rejected)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should leave pending applications when the job does not reject them", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectBegin()
+		expectLockAndCount(mock, jobID, jobRows(jobID, 1, false, ""), 1)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "jobs" WHERE id = $1 LIMIT 1 FOR UPDATE`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(jobID))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_revisions"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "job_revisions"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		job, rejected, err := repo.SyncOpenings(jobID, "Vagas preenchidas", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.JobStatusClosed, job.Status)
+		assert.Zero(t, rejected)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should save the application before counting, in the same transaction", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+		application := &models.Application{ID: uuid.New(), JobID: jobID, Status: models.ApplicationStatusApproved}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+			WithArgs(jobID).
+			WillReturnRows(jobRows(jobID, 3, false, ""))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "applications"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "jobs" SET "filled"=$1`)).
+			WithArgs(1, jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		job, _, err := repo.SaveApplicationAndSyncOpenings(application, "Vagas preenchidas", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, job.Filled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll back the application when the recount fails", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		jobID := uuid.New()
+		application := &models.Application{ID: uuid.New(), JobID: jobID, Status: models.ApplicationStatusApproved}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" WHERE id = $1`)).
+			WillReturnRows(jobRows(jobID, 3, false, ""))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "applications" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "applications"`)).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, _, err := repo.SaveApplicationAndSyncOpenings(application, "Vagas preenchidas", nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobRepository_FindAll_Search(t *testing.T) {
+	t.Run("should match accent-insensitive words, full-text search or a translation", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobRepository(db)
+		searchConfig := models.SearchConfigSQL("locale")
+		textMatch := "translate(lower(title), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE $%d OR translate(lower(description_text), 'áàâãäåéèêëíìîïóòôõöúùûüçñ', 'aaaaaaeeeeiiiiooooouuuucn') LIKE $%d"
+		where := `WHERE ((` + fmt.Sprintf(textMatch, 1, 2) + `) AND (` + fmt.Sprintf(textMatch, 3, 4) + `)` +
+			` OR search_vector @@ websearch_to_tsquery(` + searchConfig + `, $5)` +
+			` OR id IN (SELECT "job_id" FROM "job_translations" WHERE search_vector @@ websearch_to_tsquery(` + searchConfig + `, $6)))`
+		args := []driver.Value{"%engenheiro%", "%engenheiro%", "%eletrico%", "%eletrico%", "Engenheiro Elétrico", "Engenheiro Elétrico"}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "jobs" ` + where)).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "jobs" ` + where)).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		jobs, total, err := repo.FindAll(JobFilters{Search: "Engenheiro Elétrico"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, jobs)
+		assert.Zero(t, total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type JobReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewJobReviewRepository(db *gorm.DB) *JobReviewRepository {
+	return &JobReviewRepository{db: db}
+}
+
+func (r *JobReviewRepository) FindByJobID(jobID uuid.UUID) ([]models.JobReview, error) {
+	var reviews []models.JobReview
+	err := r.db.Preload("Reviewer").
+		Where("job_id = ?", jobID).
+		Order("created_at DESC").
+		Find(&reviews).Error
+	return reviews, err
+}
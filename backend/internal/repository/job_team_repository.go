@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobTeamRepository struct {
+	db *gorm.DB
+}
+
+func NewJobTeamRepository(db *gorm.DB) *JobTeamRepository {
+	return &JobTeamRepository{db: db}
+}
+
+func (r *JobTeamRepository) FindByJobID(jobID uuid.UUID) ([]models.JobTeamMember, error) {
+	var members []models.JobTeamMember
+	err := r.db.Preload("User").Where("job_id = ?", jobID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+func (r *JobTeamRepository) Find(jobID, userID uuid.UUID) (*models.JobTeamMember, error) {
+	var member models.JobTeamMember
+	err := r.db.Where("job_id = ? AND user_id = ?", jobID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Save adds the member to the team, or changes their role if they are
+// already on it.
+func (r *JobTeamRepository) Save(member *models.JobTeamMember) error {
+	return r.db.Omit("User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "added_by_id", "updated_at"}),
+	}).Create(member).Error
+}
+
+// Remove deletes the member and reports whether they were on the team.
+func (r *JobTeamRepository) Remove(jobID, userID uuid.UUID) (bool, error) {
+	result := r.db.Where("job_id = ? AND user_id = ?", jobID, userID).Delete(&models.JobTeamMember{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type JobTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewJobTemplateRepository(db *gorm.DB) *JobTemplateRepository {
+	return &JobTemplateRepository{db: db}
+}
+
+func (r *JobTemplateRepository) Create(template *models.JobTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *JobTemplateRepository) FindByID(id uuid.UUID) (*models.JobTemplate, error) {
+	var template models.JobTemplate
+	err := r.db.Where("id = ?", id).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindVisibleTo returns the user's own templates plus those shared with the
+// whole company.
+func (r *JobTemplateRepository) FindVisibleTo(userID uuid.UUID) ([]models.JobTemplate, error) {
+	var templates []models.JobTemplate
+	err := r.db.Where("owner_id = ? OR visibility = ?", userID, models.TemplateVisibilityCompany).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *JobTemplateRepository) Update(template *models.JobTemplate) error {
+	return r.db.Omit("Owner").Save(template).Error
+}
+
+func (r *JobTemplateRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.JobTemplate{}, "id = ?", id).Error
+}
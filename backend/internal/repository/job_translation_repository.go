@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobTranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewJobTranslationRepository(db *gorm.DB) *JobTranslationRepository {
+	return &JobTranslationRepository{db: db}
+}
+
+// Save creates the translation or replaces the job's translation for the
+// same locale.
+func (r *JobTranslationRepository) Save(translation *models.JobTranslation) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "description_text", "updated_at"}),
+	}).Create(translation).Error
+}
+
+// Delete removes the job's translation for the locale and reports whether
+// there was one.
+func (r *JobTranslationRepository) Delete(jobID uuid.UUID, locale models.Locale) (bool, error) {
+	result := r.db.Where("job_id = ? AND locale = ?", jobID, locale).Delete(&models.JobTranslation{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *JobTranslationRepository) FindByJobID(jobID uuid.UUID) ([]models.JobTranslation, error) {
+	var translations []models.JobTranslation
+	err := r.db.Where("job_id = ?", jobID).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+// FindForJobs returns the translations of jobIDs into any of locales,
+// grouped by job.
+func (r *JobTranslationRepository) FindForJobs(jobIDs []uuid.UUID, locales []models.Locale) (map[uuid.UUID]map[models.Locale]models.JobTranslation, error) {
+	byJob := make(map[uuid.UUID]map[models.Locale]models.JobTranslation)
+	if len(jobIDs) == 0 || len(locales) == 0 {
+		return byJob, nil
+	}
+
+	var translations []models.JobTranslation
+	err := r.db.Where("job_id IN ? AND locale IN ?", jobIDs, locales).Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range translations {
+		if byJob[t.JobID] == nil {
+			byJob[t.JobID] = make(map[models.Locale]models.JobTranslation)
+		}
+		byJob[t.JobID][t.Locale] = t
+	}
+	return byJob, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobTranslationRepository_Save(t *testing.T) {
+	t.Run("should upsert the translation with its plain-text description", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobTranslationRepository(db)
+		translation := &models.JobTranslation{
+			JobID:       uuid.New(),
+			Locale:      models.LocaleEs,
+			Title:       "Desarrollador Go",
+			Description: "Trabajo **remoto**",
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "job_translations" ("job_id","locale","title","description","description_text","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT ("job_id","locale") DO UPDATE SET "title"="excluded"."title","description"="excluded"."description","description_text"="excluded"."description_text","updated_at"="excluded"."updated_at"`)).
+			WithArgs(translation.JobID, models.LocaleEs, "Desarrollador Go", "Trabajo **remoto**", "Trabajo remoto", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(translation)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobTranslationRepository_Delete(t *testing.T) {
+	t.Run("should report whether the locale had a translation", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobTranslationRepository(db)
+		jobID := uuid.New()
+
+		for _, rows := range []int64{1, 0} {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "job_translations" WHERE job_id = $1 AND locale = $2`)).
+				WithArgs(jobID, models.LocaleEn).
+				WillReturnResult(sqlmock.NewResult(0, rows))
+			mock.ExpectCommit()
+
+			removed, err := repo.Delete(jobID, models.LocaleEn)
+
+			assert.NoError(t, err)
+			assert.Equal(t, rows == 1, removed)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobTranslationRepository_FindByJobID(t *testing.T) {
+	t.Run("should list the job's translations by locale", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobTranslationRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_translations" WHERE job_id = $1 ORDER BY locale`)).
+			WithArgs(jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "locale", "title"}).
+				AddRow(jobID, models.LocaleEn, "Go Developer").
+				AddRow(jobID, models.LocaleEs, "Desarrollador Go"))
+
+		translations, err := repo.FindByJobID(jobID)
+
+		assert.NoError(t, err)
+		require.Len(t, translations, 2)
+		assert.Equal(t, models.LocaleEn, translations[0].Locale)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestJobTranslationRepository_FindForJobs(t *testing.T) {
+	t.Run("should group the translations by job and locale", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobTranslationRepository(db)
+		first, second := uuid.New(), uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "job_translations" WHERE job_id IN ($1,$2) AND locale IN ($3,$4)`)).
+			WithArgs(first, second, models.LocaleEs, models.LocalePtBR).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "locale", "title"}).
+				AddRow(first, models.LocaleEs, "Desarrollador Go").
+				AddRow(first, models.LocalePtBR, "Desenvolvedor Go").
+				AddRow(second, models.LocaleEs, "Analista de Datos"))
+
+		byJob, err := repo.FindForJobs([]uuid.UUID{first, second}, []models.Locale{models.LocaleEs, models.LocalePtBR})
+
+		assert.NoError(t, err)
+		assert.Len(t, byJob, 2)
+		assert.Len(t, byJob[first], 2)
+		assert.Equal(t, "Analista de Datos", byJob[second][models.LocaleEs].Title)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not query without jobs or locales", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobTranslationRepository(db)
+
+		byJob, err := repo.FindForJobs(nil, []models.Locale{models.LocaleEs})
+		assert.NoError(t, err)
+		assert.Empty(t, byJob)
+
+		byJob, err = repo.FindForJobs([]uuid.UUID{uuid.New()}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, byJob)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DailyCount is a per-day total, with Day truncated to the date.
+type DailyCount struct {
+	Day   time.Time
+	Count int64
+}
+
+type JobViewRepository struct {
+	db *gorm.DB
+}
+
+func NewJobViewRepository(db *gorm.DB) *JobViewRepository {
+	return &JobViewRepository{db: db}
+}
+
+// RecordViews stores the views, ignoring those already recorded for the same
+// job, viewer and day.
+func (r *JobViewRepository) RecordViews(views []models.JobView) error {
+	if len(views) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(views, 500).Error
+}
+
+// Totals counts the job's views and distinct viewers between the from and to
+// days, inclusive.
+func (r *JobViewRepository) Totals(jobID uuid.UUID, from, to time.Time) (views, uniqueViewers int64, err error) {
+	var row struct {
+		Views         int64
+		UniqueViewers int64
+	}
+	err = r.db.Model(&models.JobView{}).
+		Select("COUNT(*) AS views, COUNT(DISTINCT viewer_key) AS unique_viewers").
+		Where("job_id = ? AND day BETWEEN ? AND ?", jobID, from, to).
+		Scan(&row).Error
+	return row.Views, row.UniqueViewers, err
+}
+
+// DailyViews counts the job's views per day between the from and to days,
+// inclusive. Days without views are omitted.
+func (r *JobViewRepository) DailyViews(jobID uuid.UUID, from, to time.Time) ([]DailyCount, error) {
+	var counts []DailyCount
+	err := r.db.Model(&models.JobView{}).
+		Select("day, COUNT(*) AS count").
+		Where("job_id = ? AND day BETWEEN ? AND ?", jobID, from, to).
+		Group("day").
+		Order("day").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// TopReferrers returns where the job's views came from between the from and
+// to days, most frequent first.
+func (r *JobViewRepository) TopReferrers(jobID uuid.UUID, from, to time.Time, limit int) ([]models.ReferrerCount, error) {
+	var referrers []models.ReferrerCount
+	err := r.db.Model(&models.JobView{}).
+		Select("referrer, COUNT(*) AS views").
+		Where("job_id = ? AND day BETWEEN ? AND ?", jobID, from, to).
+		Group("referrer").
+		Order("views DESC, referrer").
+		Limit(limit).
+		Scan(&referrers).Error
+	return referrers, err
+}
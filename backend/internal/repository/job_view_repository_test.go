@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobViewRepository_Totals(t *testing.T) {
+	t.Run("should count views and distinct viewers in the date range", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewJobViewRepository(db)
+		jobID := uuid.New()
+		from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS views, COUNT(DISTINCT viewer_key) AS unique_viewers FROM "job_views" WHERE job_id = $1 AND day BETWEEN $2 AND $3`)).
+			WithArgs(jobID, from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"views", "unique_viewers"}).AddRow(12, 7))
+
+		views, uniqueViewers, err := repo.Totals(jobID, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), views)
+		assert.Equal(t, int64(7), uniqueViewers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type KnockoutRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewKnockoutRuleRepository(db *gorm.DB) *KnockoutRuleRepository {
+	return &KnockoutRuleRepository{db: db}
+}
+
+// FindByJobID returns the job's rules in the order they were set. Rules
+// stored before positions were recorded all sit at 0 and fall back to their
+// creation order.
+func (r *KnockoutRuleRepository) FindByJobID(jobID uuid.UUID) ([]models.KnockoutRule, error) {
+	var rules []models.KnockoutRule
+	err := r.db.Where("job_id = ?", jobID).Order("position ASC, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceForJob soft-deletes the job's current rules and stores the new set,
+// numbering them in the order given. Applications keep referencing the rule
+// that screened them.
+func (r *KnockoutRuleRepository) ReplaceForJob(jobID uuid.UUID, rules []models.KnockoutRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", jobID).Delete(&models.KnockoutRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		for i := range rules {
+			rules[i].JobID = jobID
+			rules[i].Position = i + 1
+		}
+		return tx.Create(&rules).Error
+	})
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnockoutRuleRepository_FindByJobID(t *testing.T) {
+	t.Run("should return the rules by position", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewKnockoutRuleRepository(db)
+		jobID := uuid.New()
+		first, second := uuid.New(), uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "knockout_rules" WHERE job_id = $1 AND "knockout_rules"."deleted_at" IS NULL ORDER BY position ASC, created_at ASC`)).
+			WithArgs(jobID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "position", "action"}).
+				AddRow(first, jobID, 1, models.KnockoutActionFlag).
+				AddRow(second, jobID, 2, models.KnockoutActionReject))
+
+		rules, err := repo.FindByJobID(jobID)
+
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, first, rules[0].ID)
+		assert.Equal(t, 2, rules[1].Position)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestKnockoutRuleRepository_ReplaceForJob(t *testing.T) {
+	t.Run("should number the new rules in the order given", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewKnockoutRuleRepository(db)
+		jobID := uuid.New()
+		rules := []models.KnockoutRule{
+			{QuestionID: uuid.New(), Operator: models.KnockoutOperatorEquals, Value: models.JSONValue(`true`), Action: models.KnockoutActionFlag},
+			{QuestionID: uuid.New(), Operator: models.KnockoutOperatorGTE, Value: models.JSONValue(`3`), Action: models.KnockoutActionReject},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "knockout_rules" SET "deleted_at"=$1 WHERE job_id = $2 AND "knockout_rules"."deleted_at" IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), jobID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "knockout_rules"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+		mock.ExpectCommit()
+
+		err := repo.ReplaceForJob(jobID, rules)
+
+		require.NoError(t, err)
+		for i, rule := range rules {
+			assert.Equal(t, jobID, rule.JobID)
+			assert.Equal(t, i+1, rule.Position)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should only clear the rules when the new set is empty", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		repo := NewKnockoutRuleRepository(db)
+		jobID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "knockout_rules" SET "deleted_at"=$1 WHERE job_id = $2`)).
+			WithArgs(sqlmock.AnyArg(), jobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.ReplaceForJob(jobID, nil)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
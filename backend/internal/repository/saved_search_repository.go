@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"gorm.io/gorm"
+)
+
+type SavedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+func (r *SavedSearchRepository) Create(search *models.SavedSearch) error {
+	return r.db.Omit("Candidate").Create(search).Error
+}
+
+func (r *SavedSearchRepository) FindByID(id uuid.UUID) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.Where("id = ?", id).First(&search).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *SavedSearchRepository) FindByCandidateID(candidateID uuid.UUID) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.db.Where("candidate_id = ?", candidateID).
+		Order("created_at DESC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepository) FindByUnsubscribeToken(token string) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.Where("unsubscribe_token = ?", token).First(&search).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// FindDue returns the searches with alerts enabled whose frequency interval
+// has elapsed since they were last checked, with their candidate loaded.
+func (r *SavedSearchRepository) FindDue(now time.Time) ([]models.SavedSearch, error) {
+	due := r.db.Where("1 = 0")
+	for frequency, interval := range models.AlertFrequencies() {
+		due = due.Or("frequency = ? AND last_checked_at <= ?", frequency, now.Add(-interval))
+	}
+
+	var searches []models.SavedSearch
+	err := r.db.Preload("Candidate").
+		Where("alerts_enabled = ?", true).
+		Where(due).
+		Order("last_checked_at ASC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepository) Update(search *models.SavedSearch) error {
+	return r.db.Omit("Candidate").Save(search).Error
+}
+
+// MarkChecked moves the search's alert window forward to at.
+func (r *SavedSearchRepository) MarkChecked(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.SavedSearch{}).Where("id = ?", id).UpdateColumn("last_checked_at", at).Error
+}
+
+func (r *SavedSearchRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.SavedSearch{}, "id = ?", id).Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/geo"
+)
+
+// BuildJobFilters turns search criteria into listing filters, resolving the
+// near location and skill names. matchable is false when the skills rule out
+// every job: "all" with an unknown skill, or only unknown skills.
+func BuildJobFilters(criteria models.SearchCriteria, skillRepo *SkillRepository) (filters JobFilters, matchable bool, err error) {
+	filters = JobFilters{
+		Search:         criteria.Search,
+		Location:       criteria.Location,
+		Type:           string(criteria.Type),
+		SalaryMin:      criteria.SalaryMin,
+		SalaryMax:      criteria.SalaryMax,
+		SalaryPeriod:   criteria.SalaryPeriod,
+		SalaryCurrency: criteria.SalaryCurrency,
+		SkillsMatch:    criteria.SkillsMatch,
+	}
+
+	if criteria.Near != "" {
+		place, ok := geo.Lookup(criteria.Near)
+		if !ok {
+			return filters, false, models.ErrUnsupportedLocation
+		}
+		filters.Near = &place
+		filters.RadiusKm = criteria.RadiusKm
+		if filters.RadiusKm == 0 {
+			filters.RadiusKm = geo.DefaultRadiusKm
+		}
+	}
+
+	if len(criteria.Skills) > 0 {
+		skills, unknown, err := skillRepo.Resolve(criteria.Skills)
+		if err != nil {
+			return filters, false, err
+		}
+		if len(skills) == 0 || (criteria.SkillsMatch == "all" && len(unknown) > 0) {
+			return filters, false, nil
+		}
+		for _, skill := range skills {
+			filters.SkillIDs = append(filters.SkillIDs, skill.ID)
+		}
+	}
+
+	return filters, true, nil
+}
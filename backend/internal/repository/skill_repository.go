@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var ErrSkillExists = errors.New("skill or alias already exists")
+
+type SkillRepository struct {
+	db *gorm.DB
+}
+
+// SkillCount is a skill with the number of open jobs asking for it.
+type SkillCount struct {
+	Skill    models.Skill
+	OpenJobs int64
+}
+
+func NewSkillRepository(db *gorm.DB) *SkillRepository {
+	return &SkillRepository{db: db}
+}
+
+// Create stores a skill with its aliases. Names and aliases share one
+// namespace, so neither may match an existing skill or alias.
+func (r *SkillRepository) Create(skill *models.Skill) error {
+	keys := []string{utils.NormalizeKey(skill.Name)}
+	for _, alias := range skill.Aliases {
+		keys = append(keys, utils.NormalizeKey(alias.Alias))
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		err := tx.Raw(
+			"SELECT (SELECT COUNT(*) FROM skills WHERE key IN ?) + (SELECT COUNT(*) FROM skill_aliases WHERE key IN ?)",
+			keys, keys,
+		).Scan(&taken).Error
+		if err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrSkillExists
+		}
+		return tx.Create(skill).Error
+	})
+}
+
+// Resolve maps skill names or aliases to skills, returning the names that
+// matched nothing. Names resolving to the same skill yield it once.
+func (r *SkillRepository) Resolve(names []string) ([]models.Skill, []string, error) {
+	keys := make([]string, 0, len(names))
+	requested := make([]string, 0, len(names))
+	for _, name := range names {
+		if key := utils.NormalizeKey(name); key != "" {
+			keys = append(keys, key)
+			requested = append(requested, name)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil, nil
+	}
+
+	var skills []models.Skill
+	err := r.db.Preload("Aliases").
+		Where("key IN ?", keys).
+		Or("id IN (?)", r.db.Model(&models.SkillAlias{}).Select("skill_id").Where("key IN ?", keys)).
+		Find(&skills).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, skill := range skills {
+		known[skill.Key] = true
+		for _, alias := range skill.Aliases {
+			known[alias.Key] = true
+		}
+	}
+
+	var unknown []string
+	for i, key := range keys {
+		if !known[key] {
+			unknown = append(unknown, requested[i])
+		}
+	}
+
+	return skills, unknown, nil
+}
+
+// FindAllWithOpenJobs lists the taxonomy with the number of jobs open to the
+// public at now that ask for each skill, most demanded first.
+func (r *SkillRepository) FindAllWithOpenJobs(now time.Time) ([]SkillCount, error) {
+	var rows []struct {
+		ID       uuid.UUID
+		OpenJobs int64
+	}
+	err := r.db.Table("skills").
+		Select("skills.id, COUNT(jobs.id) AS open_jobs").
+		Joins("LEFT JOIN job_skills ON job_skills.skill_id = skills.id").
+		Joins(`LEFT JOIN jobs ON jobs.id = job_skills.job_id AND jobs.deleted_at IS NULL AND jobs.status = ?
+			AND (jobs.publish_at IS NULL OR jobs.publish_at <= ?)
+			AND (jobs.expires_at IS NULL OR jobs.expires_at > ?)`, models.JobStatusOpen, now, now).
+		Group("skills.id, skills.name").
+		Order("open_jobs DESC, skills.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	var skills []models.Skill
+	if len(ids) > 0 {
+		if err := r.db.Preload("Aliases").Where("id IN ?", ids).Find(&skills).Error; err != nil {
+			return nil, err
+		}
+	}
+	byID := make(map[uuid.UUID]models.Skill, len(skills))
+	for _, skill := range skills {
+		byID[skill.ID] = skill
+	}
+
+	counts := make([]SkillCount, len(rows))
+	for i, row := range rows {
+		counts[i] = SkillCount{Skill: byID[row.ID], OpenJobs: row.OpenJobs}
+	}
+	return counts, nil
+}
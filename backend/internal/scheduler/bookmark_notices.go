@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/mailer"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+// BookmarkClosingNotices warns candidates, in one email each, about the
+// bookmarked jobs that stop taking applications, by deadline or expiry,
+// within window. Each bookmark is warned about once per closing time.
+func BookmarkClosingNotices(
+	bookmarkRepo *repository.BookmarkRepository,
+	m mailer.Mailer,
+	baseURL string,
+	window time.Duration,
+) Task {
+	return func(ctx context.Context) error {
+		now := time.Now()
+
+		bookmarks, err := bookmarkRepo.FindClosingSoon(now, window)
+		if err != nil {
+			return fmt.Errorf("failed to load bookmarks closing soon: %w", err)
+		}
+
+		var order []uuid.UUID
+		byCandidate := make(map[uuid.UUID][]models.Bookmark)
+		for _, bookmark := range bookmarks {
+			if _, ok := byCandidate[bookmark.CandidateID]; !ok {
+				order = append(order, bookmark.CandidateID)
+			}
+			byCandidate[bookmark.CandidateID] = append(byCandidate[bookmark.CandidateID], bookmark)
+		}
+
+		var sent int
+		var failed error
+		for _, candidateID := range order {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			group := byCandidate[candidateID]
+			if group[0].Candidate.Email != "" {
+				if err := m.Send(ctx, bookmarkClosingMessage(group, baseURL)); err != nil {
+					log.Printf("Bookmark closing notice for candidate %s failed: %v", candidateID, err)
+					failed = err
+					continue
+				}
+				sent++
+			}
+
+			for _, bookmark := range group {
+				if err := bookmarkRepo.MarkClosingNoticeSent(bookmark.CandidateID, bookmark.JobID, now); err != nil {
+					return fmt.Errorf("failed to update bookmark: %w", err)
+				}
+			}
+		}
+
+		if sent > 0 {
+			log.Printf("Sent %d bookmark closing notice(s)", sent)
+		}
+		if failed != nil {
+			return fmt.Errorf("failed to send some bookmark closing notices: %w", failed)
+		}
+		return nil
+	}
+}
+
+// bookmarkClosingMessage renders the warning for one candidate's bookmarks,
+// which must all belong to the same candidate.
+func bookmarkClosingMessage(bookmarks []models.Bookmark, baseURL string) mailer.Message {
+	var body strings.Builder
+	if len(bookmarks) == 1 {
+		body.WriteString("Uma vaga que você salvou está prestes a encerrar as candidaturas:\n\n")
+	} else {
+		fmt.Fprintf(&body, "%d vagas que você salvou estão prestes a encerrar as candidaturas:\n\n", len(bookmarks))
+	}
+
+	for _, bookmark := range bookmarks {
+		job := bookmark.Job
+		fmt.Fprintf(&body, "%s\n%s · encerra em %s\n%s/api/jobs/%s\n\n",
+			job.Title, job.Location, job.ApplicationsCloseAt().Format("02/01/2006 15:04 MST"), baseURL, job.ID)
+	}
+
+	subject := "Vaga salva prestes a encerrar"
+	if len(bookmarks) > 1 {
+		subject = "Vagas salvas prestes a encerrar"
+	}
+
+	return mailer.Message{
+		To:      bookmarks[0].Candidate.Email,
+		Subject: subject,
+		Body:    body.String(),
+	}
+}
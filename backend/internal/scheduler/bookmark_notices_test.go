@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkClosingMessage(t *testing.T) {
+	expiresAt := time.Date(2024, 3, 12, 18, 0, 0, 0, time.UTC)
+	candidate := models.User{Email: "ana@example.com"}
+	bookmark := func(title string) models.Bookmark {
+		return models.Bookmark{
+			Candidate: candidate,
+			Job:       models.Job{ID: uuid.New(), Title: title, Location: "Remoto", ExpiresAt: &expiresAt},
+		}
+	}
+
+	t.Run("should warn about a single job", func(t *testing.T) {
+		b := bookmark("Desenvolvedor Go")
+
+		msg := bookmarkClosingMessage([]models.Bookmark{b}, "https://vagas.example.com")
+
+		assert.Equal(t, "ana@example.com", msg.To)
+		assert.Equal(t, "Vaga salva prestes a encerrar", msg.Subject)
+		assert.Contains(t, msg.Body, "Desenvolvedor Go")
+		assert.Contains(t, msg.Body, "12/03/2024 18:00")
+		assert.Contains(t, msg.Body, "https://vagas.example.com/api/jobs/"+b.Job.ID.String())
+	})
+
+	t.Run("should group several jobs in one message", func(t *testing.T) {
+		msg := bookmarkClosingMessage([]models.Bookmark{bookmark("Go"), bookmark("SRE")}, "https://vagas.example.com")
+
+		assert.Equal(t, "Vagas salvas prestes a encerrar", msg.Subject)
+		assert.Contains(t, msg.Body, "2 vagas que você salvou")
+	})
+
+	t.Run("should use the application deadline when it comes first", func(t *testing.T) {
+		b := bookmark("Desenvolvedor Go")
+		deadline := expiresAt.Add(-24 * time.Hour)
+		b.Job.ApplicationDeadline = &deadline
+
+		msg := bookmarkClosingMessage([]models.Bookmark{b}, "https://vagas.example.com")
+
+		assert.Contains(t, msg.Body, "11/03/2024 18:00")
+	})
+}
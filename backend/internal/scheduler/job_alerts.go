@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/mailer"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+// maxAlertJobs caps how many jobs a single alert email lists.
+const maxAlertJobs = 20
+
+// JobAlerts emails candidates the jobs published since their saved searches
+// were last checked. A search is only moved forward once its email is sent,
+// so a delivery failure is retried on the next run.
+func JobAlerts(
+	searchRepo *repository.SavedSearchRepository,
+	jobRepo *repository.JobRepository,
+	skillRepo *repository.SkillRepository,
+	m mailer.Mailer,
+	baseURL string,
+) Task {
+	return func(ctx context.Context) error {
+		now := time.Now()
+
+		searches, err := searchRepo.FindDue(now)
+		if err != nil {
+			return fmt.Errorf("failed to load saved searches: %w", err)
+		}
+
+		var sent int
+		var failed error
+		for i := range searches {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			search := &searches[i]
+			ok, err := sendJobAlert(ctx, search, jobRepo, skillRepo, m, baseURL, now)
+			if err != nil {
+				log.Printf("Job alert for saved search %s failed: %v", search.ID, err)
+				failed = err
+				continue
+			}
+			if ok {
+				sent++
+			}
+
+			if err := searchRepo.MarkChecked(search.ID, now); err != nil {
+				return fmt.Errorf("failed to update saved search %s: %w", search.ID, err)
+			}
+		}
+
+		if sent > 0 {
+			log.Printf("Sent %d job alert(s)", sent)
+		}
+		if failed != nil {
+			return fmt.Errorf("failed to send some job alerts: %w", failed)
+		}
+		return nil
+	}
+}
+
+// sendJobAlert emails the jobs matching search that were published in
+// (search.LastCheckedAt, now]. It reports whether an email was sent.
+func sendJobAlert(
+	ctx context.Context,
+	search *models.SavedSearch,
+	jobRepo *repository.JobRepository,
+	skillRepo *repository.SkillRepository,
+	m mailer.Mailer,
+	baseURL string,
+	now time.Time,
+) (bool, error) {
+	// The candidate's account is gone; there is nobody to notify.
+	if search.Candidate.Email == "" {
+		return false, nil
+	}
+
+	filters, matchable, err := repository.BuildJobFilters(search.Criteria, skillRepo)
+	if err != nil || !matchable {
+		// Criteria that no longer resolve, e.g. a location dropped from the
+		// gazetteer, match nothing rather than failing every run.
+		if errors.Is(err, models.ErrUnsupportedLocation) {
+			err = nil
+		}
+		return false, err
+	}
+
+	filters.Status = string(models.JobStatusOpen)
+	filters.VisibleAt = &now
+	filters.PublishedAfter = &search.LastCheckedAt
+	filters.PublishedBefore = &now
+	filters.SortBy = "published_at"
+	filters.Order = "DESC"
+	filters.Limit = maxAlertJobs
+
+	jobs, total, err := jobRepo.FindAll(filters)
+	if err != nil {
+		return false, err
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+
+	return true, m.Send(ctx, jobAlertMessage(search, jobs, total, baseURL))
+}
+
+// jobAlertMessage renders the alert email for search listing jobs out of
+// total new matches.
+func jobAlertMessage(search *models.SavedSearch, jobs []models.Job, total int64, baseURL string) mailer.Message {
+	unsubscribeURL := baseURL + "/api/saved-searches/unsubscribe/" + search.UnsubscribeToken
+
+	var body strings.Builder
+	if total == 1 {
+		fmt.Fprintf(&body, "Há 1 nova vaga para a sua busca \"%s\":\n\n", search.Name)
+	} else {
+		fmt.Fprintf(&body, "Há %d novas vagas para a sua busca \"%s\":\n\n", total, search.Name)
+	}
+
+	for _, job := range jobs {
+		fmt.Fprintf(&body, "%s\n%s · %s\n%s/api/jobs/%s\n\n", job.Title, job.Location, job.Type, baseURL, job.ID)
+	}
+	if remaining := total - int64(len(jobs)); remaining > 0 {
+		fmt.Fprintf(&body, "E mais %d vaga(s). Refaça a busca para ver todas.\n\n", remaining)
+	}
+
+	fmt.Fprintf(&body, "Para não receber mais estes alertas, acesse:\n%s\n", unsubscribeURL)
+
+	subject := fmt.Sprintf("Novas vagas para \"%s\"", search.Name)
+
+	return mailer.Message{
+		To:      search.Candidate.Email,
+		Subject: subject,
+		Body:    body.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ledufranco/recruitment-system/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobAlertMessage(t *testing.T) {
+	search := &models.SavedSearch{
+		Name:             "Go remoto",
+		UnsubscribeToken: "abc123",
+		Candidate:        models.User{Email: "ana@example.com"},
+	}
+	jobs := []models.Job{
+		{ID: uuid.New(), Title: "Desenvolvedor Go", Location: "Remoto", Type: models.JobTypeRemote},
+	}
+
+	t.Run("should list jobs with links and a one-click unsubscribe", func(t *testing.T) {
+		msg := jobAlertMessage(search, jobs, 1, "https://vagas.example.com")
+
+		assert.Equal(t, "ana@example.com", msg.To)
+		assert.Contains(t, msg.Subject, "Go remoto")
+		assert.Contains(t, msg.Body, "Há 1 nova vaga")
+		assert.Contains(t, msg.Body, "https://vagas.example.com/api/jobs/"+jobs[0].ID.String())
+		assert.Contains(t, msg.Body, "https://vagas.example.com/api/saved-searches/unsubscribe/abc123")
+		assert.Equal(t, "<https://vagas.example.com/api/saved-searches/unsubscribe/abc123>", msg.Headers["List-Unsubscribe"])
+		assert.Equal(t, "List-Unsubscribe=One-Click", msg.Headers["List-Unsubscribe-Post"])
+	})
+
+	t.Run("should mention matches beyond the listed jobs", func(t *testing.T) {
+		msg := jobAlertMessage(search, jobs, 25, "https://vagas.example.com")
+
+		assert.Contains(t, msg.Body, "Há 25 novas vagas")
+		assert.Contains(t, msg.Body, "E mais 24 vaga(s)")
+	})
+}
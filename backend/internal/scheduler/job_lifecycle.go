@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+// JobLifecycle publishes scheduled jobs whose publish_at has been reached and
+// closes open jobs past their expires_at.
+func JobLifecycle(jobRepo *repository.JobRepository) Task {
+	return func(ctx context.Context) error {
+		now := time.Now()
+
+		published, err := jobRepo.PublishDue(now)
+		if err != nil {
+			return fmt.Errorf("failed to publish scheduled jobs: %w", err)
+		}
+		if published > 0 {
+			log.Printf("Published %d scheduled job(s)", published)
+		}
+
+		closed, err := jobRepo.CloseExpired(now)
+		if err != nil {
+			return fmt.Errorf("failed to close expired jobs: %w", err)
+		}
+		if closed > 0 {
+			log.Printf("Closed %d expired job(s)", closed)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ledufranco/recruitment-system/internal/repository"
+)
+
+// PurgeTrash permanently deletes jobs that have been in the trash for longer
+// than retention, along with their applications.
+func PurgeTrash(jobRepo *repository.JobRepository, retention time.Duration) Task {
+	return func(ctx context.Context) error {
+		purged, err := jobRepo.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			return fmt.Errorf("failed to purge deleted jobs: %w", err)
+		}
+		if purged > 0 {
+			log.Printf("Purged %d deleted job(s)", purged)
+		}
+		return nil
+	}
+}
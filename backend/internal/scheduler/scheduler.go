@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Task is a unit of background work run periodically by the Scheduler.
+type Task func(ctx context.Context) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	task     Task
+}
+
+// Scheduler runs registered tasks on fixed intervals inside the server
+// process until its context is cancelled.
+type Scheduler struct {
+	entries []entry
+	wg      sync.WaitGroup
+	// newTicker starts the ticker that paces a task loop. Tests replace it
+	// to tick by hand instead of waiting on the wall clock.
+	newTicker func(interval time.Duration) (ticks <-chan time.Time, stop func())
+}
+
+func New() *Scheduler {
+	return &Scheduler{newTicker: wallTicker}
+}
+
+func wallTicker(interval time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(interval)
+	return ticker.C, ticker.Stop
+}
+
+// Every registers task to run immediately on Start and then every interval.
+func (s *Scheduler) Every(name string, interval time.Duration, task Task) {
+	s.entries = append(s.entries, entry{name: name, interval: interval, task: task})
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.run(ctx, e)
+	}
+}
+
+// Wait blocks until every task loop has stopped.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, e entry) {
+	defer s.wg.Done()
+
+	ticks, stop := s.newTicker(e.interval)
+	defer stop()
+
+	for {
+		if err := e.task(ctx); err != nil {
+			log.Printf("Scheduled task %s failed: %v", e.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+		}
+	}
+}
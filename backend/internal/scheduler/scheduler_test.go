@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// manualTicker stands in for the wall clock: every registered task ticks
+// only when the test sends on ticks.
+type manualTicker struct {
+	ticks chan time.Time
+}
+
+func newManualScheduler() (*Scheduler, *manualTicker) {
+	clock := &manualTicker{ticks: make(chan time.Time)}
+	s := New()
+	s.newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return clock.ticks, func() {}
+	}
+	return s, clock
+}
+
+func (m *manualTicker) tick(t *testing.T) {
+	t.Helper()
+	select {
+	case m.ticks <- time.Now():
+	case <-time.After(time.Second):
+		t.Fatal("no task loop waiting for a tick")
+	}
+}
+
+func waitRun(t *testing.T, runs <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("task did not run")
+	}
+}
+
+func waitStopped(t *testing.T, s *Scheduler) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		s.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("should run task immediately and on every tick", func(t *testing.T) {
+		s, clock := newManualScheduler()
+		runs := make(chan struct{}, 1)
+		s.Every("counter", time.Minute, func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s.Start(ctx)
+
+		waitRun(t, runs)
+		clock.tick(t)
+		waitRun(t, runs)
+		clock.tick(t)
+		waitRun(t, runs)
+
+		cancel()
+		waitStopped(t, s)
+	})
+
+	t.Run("should keep running after a task error", func(t *testing.T) {
+		s, clock := newManualScheduler()
+		runs := make(chan struct{}, 1)
+		s.Every("failing", time.Minute, func(ctx context.Context) error {
+			runs <- struct{}{}
+			return errors.New("boom")
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s.Start(ctx)
+
+		waitRun(t, runs)
+		clock.tick(t)
+		waitRun(t, runs)
+
+		cancel()
+		waitStopped(t, s)
+	})
+
+	t.Run("should not run again before the next tick", func(t *testing.T) {
+		s, _ := newManualScheduler()
+		runs := make(chan struct{}, 2)
+		s.Every("once", time.Minute, func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.Start(ctx)
+		waitRun(t, runs)
+		cancel()
+		waitStopped(t, s)
+
+		assert.Empty(t, runs)
+	})
+
+	t.Run("should wait for a running task to finish", func(t *testing.T) {
+		s, _ := newManualScheduler()
+		started := make(chan struct{})
+		finished := make(chan struct{})
+		s.Every("slow", time.Minute, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(finished)
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.Start(ctx)
+		<-started
+		cancel()
+		waitStopped(t, s)
+
+		select {
+		case <-finished:
+		default:
+			t.Fatal("Wait returned while the task was still running")
+		}
+	})
+
+	t.Run("should stop when context is cancelled", func(t *testing.T) {
+		s := New()
+		s.Every("noop", time.Hour, func(ctx context.Context) error { return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.Start(ctx)
+		cancel()
+
+		waitStopped(t, s)
+	})
+}
@@ -0,0 +1,184 @@
+// Package geo resolves Brazilian city names against a bundled offline
+// gazetteer and computes great-circle distances.
+package geo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ledufranco/recruitment-system/pkg/utils"
+)
+
+const (
+	Country       = "BR"
+	EarthRadiusKm = 6371.0
+
+	// DefaultRadiusKm and MaxRadiusKm bound radius searches.
+	DefaultRadiusKm = 50
+	MaxRadiusKm     = 1000
+)
+
+// Place is a municipality from the gazetteer.
+type Place struct {
+	City      string
+	State     string
+	Country   string
+	Latitude  float64
+	Longitude float64
+}
+
+// municipalitiesCSV holds one municipality per line as
+// city,state,latitude,longitude. It ships the state capitals and the larger
+// municipalities; LoadFile replaces it with a fuller list, such as the IBGE
+// table of every municipality, in the same format.
+//
+//go:embed municipalities.csv
+var municipalitiesCSV string
+
+type gazetteer struct {
+	places []Place
+	byName map[string][]int
+}
+
+var (
+	bundledOnce sync.Once
+	bundled     *gazetteer
+	loaded      atomic.Pointer[gazetteer]
+)
+
+// current returns the gazetteer loaded by LoadFile or, without one, the
+// bundled list.
+func current() *gazetteer {
+	if g := loaded.Load(); g != nil {
+		return g
+	}
+	bundledOnce.Do(func() {
+		g, err := parse(strings.NewReader(municipalitiesCSV))
+		if err != nil {
+			panic("geo: invalid municipalities.csv: " + err.Error())
+		}
+		bundled = g
+	})
+	return bundled
+}
+
+// LoadFile replaces the bundled gazetteer with the municipalities in the CSV
+// file at path, in the city,state,latitude,longitude format with a header
+// line.
+func LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	g, err := parse(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	loaded.Store(g)
+	return nil
+}
+
+func parse(r io.Reader) (*gazetteer, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no municipalities")
+	}
+
+	g := &gazetteer{byName: make(map[string][]int)}
+	for _, record := range records[1:] {
+		lat, errLat := strconv.ParseFloat(record[2], 64)
+		lng, errLng := strconv.ParseFloat(record[3], 64)
+		if errLat != nil || errLng != nil {
+			return nil, fmt.Errorf("invalid coordinates for %s", record[0])
+		}
+		g.places = append(g.places, Place{
+			City:      record[0],
+			State:     strings.ToUpper(record[1]),
+			Country:   Country,
+			Latitude:  lat,
+			Longitude: lng,
+		})
+		key := utils.NormalizeText(record[0])
+		g.byName[key] = append(g.byName[key], len(g.places)-1)
+	}
+	return g, nil
+}
+
+// Lookup resolves free-text locations such as "Niterói", "niteroi - RJ" or
+// "Rio de Janeiro, RJ, Brasil". Matching ignores case and accents. Without
+// a state, a name shared by several states resolves to the first listed.
+func Lookup(location string) (Place, bool) {
+	g := current()
+
+	parts := strings.FieldsFunc(location, func(r rune) bool {
+		return r == ',' || r == '-' || r == '/'
+	})
+	if len(parts) == 0 {
+		return Place{}, false
+	}
+
+	// Try the longest prefix first so "São José dos Campos" wins over
+	// "São José" when the name itself contains a separator.
+	for n := len(parts); n > 0; n-- {
+		name := utils.NormalizeText(strings.Join(parts[:n], " "))
+		candidates, ok := g.byName[name]
+		if !ok {
+			continue
+		}
+
+		state := ""
+		if n < len(parts) {
+			state = strings.ToUpper(strings.TrimSpace(parts[n]))
+		}
+		for _, i := range candidates {
+			if state == "" || g.places[i].State == state {
+				return g.places[i], true
+			}
+		}
+		if state == "" {
+			return g.places[candidates[0]], true
+		}
+	}
+
+	return Place{}, false
+}
+
+// DistanceKm returns the great-circle distance between two points using the
+// haversine formula.
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude/longitude ranges that contain every point
+// within radiusKm of the center. It is used to narrow down candidates before
+// the exact distance check.
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+	dLng := dLat / math.Max(math.Cos(radians(lat)), 0.01)
+	return lat - dLat, lat + dLat, lng - dLng, lng + dLng
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		city     string
+		state    string
+		expected bool
+	}{
+		{name: "should resolve city and state", input: "Rio de Janeiro, RJ", city: "Rio de Janeiro", state: "RJ", expected: true},
+		{name: "should ignore accents and case", input: "niteroi", city: "Niterói", state: "RJ", expected: true},
+		{name: "should accept dash separators", input: "São Paulo - SP", city: "São Paulo", state: "SP", expected: true},
+		{name: "should keep hyphenated names", input: "Ji-Paraná, RO", city: "Ji-Paraná", state: "RO", expected: true},
+		{name: "should ignore trailing country", input: "Curitiba, PR, Brasil", city: "Curitiba", state: "PR", expected: true},
+		{name: "should reject mismatched state", input: "Campinas, RJ", expected: false},
+		{name: "should reject unknown places", input: "Lisboa", expected: false},
+		{name: "should reject empty input", input: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			place, ok := Lookup(tt.input)
+			assert.Equal(t, tt.expected, ok)
+			if tt.expected {
+				assert.Equal(t, tt.city, place.City)
+				assert.Equal(t, tt.state, place.State)
+				assert.Equal(t, Country, place.Country)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "municipios.csv")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("should replace the bundled gazetteer", func(t *testing.T) {
+		t.Cleanup(func() { loaded.Store(nil) })
+		_, bundled := Lookup("Paraty")
+		require.False(t, bundled)
+
+		require.NoError(t, LoadFile(write(t, "city,state,latitude,longitude\nParaty,RJ,-23.2178,-44.7131\n")))
+
+		place, ok := Lookup("paraty - rj")
+		assert.True(t, ok)
+		assert.Equal(t, "Paraty", place.City)
+		assert.InDelta(t, -23.2178, place.Latitude, 0.0001)
+	})
+
+	t.Run("should keep the current gazetteer when the file is invalid", func(t *testing.T) {
+		t.Cleanup(func() { loaded.Store(nil) })
+
+		assert.Error(t, LoadFile(write(t, "city,state,latitude,longitude\nParaty,RJ,norte,-44.7131\n")))
+		assert.Error(t, LoadFile(write(t, "city,state,latitude,longitude\n")))
+		assert.Error(t, LoadFile(filepath.Join(t.TempDir(), "missing.csv")))
+
+		_, ok := Lookup("Niterói")
+		assert.True(t, ok)
+	})
+}
+
+func TestDistanceKm(t *testing.T) {
+	t.Run("should be zero for the same point", func(t *testing.T) {
+		assert.InDelta(t, 0, DistanceKm(-22.9068, -43.1729, -22.9068, -43.1729), 0.001)
+	})
+
+	t.Run("should measure Rio de Janeiro to Niterói", func(t *testing.T) {
+		rio, _ := Lookup("Rio de Janeiro")
+		niteroi, _ := Lookup("Niterói")
+		assert.InDelta(t, 9, DistanceKm(rio.Latitude, rio.Longitude, niteroi.Latitude, niteroi.Longitude), 3)
+	})
+
+	t.Run("should measure Rio de Janeiro to São Paulo", func(t *testing.T) {
+		rio, _ := Lookup("Rio de Janeiro")
+		sp, _ := Lookup("São Paulo")
+		assert.InDelta(t, 360, DistanceKm(rio.Latitude, rio.Longitude, sp.Latitude, sp.Longitude), 10)
+	})
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("should contain points within the radius", func(t *testing.T) {
+		rio, _ := Lookup("Rio de Janeiro")
+		niteroi, _ := Lookup("Niterói")
+
+		minLat, maxLat, minLng, maxLng := BoundingBox(rio.Latitude, rio.Longitude, 20)
+
+		assert.True(t, niteroi.Latitude >= minLat && niteroi.Latitude <= maxLat)
+		assert.True(t, niteroi.Longitude >= minLng && niteroi.Longitude <= maxLng)
+	})
+}
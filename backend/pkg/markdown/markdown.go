@@ -0,0 +1,219 @@
+// Package markdown renders the Markdown subset used in job descriptions to
+// safe HTML and to plain text.
+//
+// Supported syntax: paragraphs (single line breaks are kept), # headings,
+// "-", "*", "+" and "•" bullet lists, numbered lists, > quotes, fenced code
+// blocks, horizontal rules, **bold**, *italic*, `code` and [links](url).
+// Raw HTML is never passed through: it is escaped like any other text.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// linkPattern allows one level of balanced parentheses in link targets, as
+// in https://pt.wikipedia.org/wiki/Go_(linguagem).
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	bulletPattern    = regexp.MustCompile(`^\s*[-*+•]\s+(.*)$`)
+	numberedPattern  = regexp.MustCompile(`^\s*\d{1,9}[.)]\s+(.*)$`)
+	quotePattern     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	rulePattern      = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	fencePattern     = regexp.MustCompile("^\\s*(```|~~~)")
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	boldPattern      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	italicPattern    = regexp.MustCompile(`\*([^\s*](?:[^*]*?[^\s*])?)\*|(?:^|\b)_([^\s_](?:[^_]*?[^\s_])?)_(?:\b|$)`)
+	placeholderRegex = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// ToHTML renders Markdown to sanitized HTML.
+func ToHTML(source string) string {
+	return Sanitize(render(source))
+}
+
+// block kinds of the renderer's line-based state machine.
+const (
+	blockNone = iota
+	blockParagraph
+	blockBullets
+	blockNumbers
+	blockQuote
+)
+
+type renderer struct {
+	out   strings.Builder
+	kind  int
+	lines []string
+}
+
+func render(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := strings.Split(source, "\n")
+
+	r := &renderer{}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if fence := fencePattern.FindStringSubmatch(line); fence != nil {
+			r.flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence[1]); i++ {
+				code = append(code, lines[i])
+			}
+			r.out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			r.flush()
+			continue
+		}
+
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			r.flush()
+			level := len(m[1])
+			fmt.Fprintf(&r.out, "<h%d>%s</h%d>\n", level, inline(m[2]), level)
+			continue
+		}
+
+		if rulePattern.MatchString(line) {
+			r.flush()
+			r.out.WriteString("<hr>\n")
+			continue
+		}
+
+		if m := bulletPattern.FindStringSubmatch(line); m != nil {
+			r.add(blockBullets, m[1])
+			continue
+		}
+		if m := numberedPattern.FindStringSubmatch(line); m != nil {
+			r.add(blockNumbers, m[1])
+			continue
+		}
+		if m := quotePattern.FindStringSubmatch(line); m != nil {
+			r.add(blockQuote, m[1])
+			continue
+		}
+
+		// A line without a marker continues the current list item or quote,
+		// like a wrapped line would.
+		if (r.kind == blockBullets || r.kind == blockNumbers || r.kind == blockQuote) && len(r.lines) > 0 {
+			r.lines[len(r.lines)-1] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		r.add(blockParagraph, strings.TrimSpace(line))
+	}
+	r.flush()
+
+	return r.out.String()
+}
+
+func (r *renderer) add(kind int, line string) {
+	if r.kind != kind {
+		r.flush()
+		r.kind = kind
+	}
+	r.lines = append(r.lines, line)
+}
+
+func (r *renderer) flush() {
+	switch r.kind {
+	case blockParagraph:
+		r.out.WriteString("<p>" + inlineLines(r.lines) + "</p>\n")
+	case blockQuote:
+		r.out.WriteString("<blockquote><p>" + inlineLines(r.lines) + "</p></blockquote>\n")
+	case blockBullets, blockNumbers:
+		tag := "ul"
+		if r.kind == blockNumbers {
+			tag = "ol"
+		}
+		r.out.WriteString("<" + tag + ">\n")
+		for _, item := range r.lines {
+			r.out.WriteString("<li>" + inlineLines(strings.Split(item, "\n")) + "</li>\n")
+		}
+		r.out.WriteString("</" + tag + ">\n")
+	}
+	r.kind = blockNone
+	r.lines = nil
+}
+
+// inlineLines renders lines of one block, keeping their line breaks.
+func inlineLines(lines []string) string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		rendered[i] = inline(line)
+	}
+	return strings.Join(rendered, "<br>\n")
+}
+
+// inline renders the inline syntax of text. Everything is escaped first, so
+// markup can only come from the syntax itself.
+func inline(text string) string {
+	var out strings.Builder
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			out.WriteString("<code>" + html.EscapeString(part) + "</code>")
+		case i%2 == 1:
+			// An unmatched backtick is literal.
+			out.WriteString("`" + emphasis(part))
+		default:
+			out.WriteString(emphasis(part))
+		}
+	}
+	return out.String()
+}
+
+// emphasis renders links, bold and italic. Links are swapped for
+// placeholders while emphasis is applied so underscores and asterisks in
+// URLs are left alone.
+func emphasis(text string) string {
+	text = html.EscapeString(text)
+
+	var links []string
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := linkPattern.FindStringSubmatch(match)
+		label, href := applyEmphasis(m[1]), html.UnescapeString(m[2])
+		if !safeURL(href) {
+			links = append(links, label)
+		} else {
+			links = append(links, `<a href="`+html.EscapeString(href)+`" rel="nofollow noopener noreferrer">`+label+`</a>`)
+		}
+		return fmt.Sprintf("\x00%d\x00", len(links)-1)
+	})
+
+	text = applyEmphasis(text)
+
+	return placeholderRegex.ReplaceAllStringFunc(text, func(match string) string {
+		var n int
+		fmt.Sscanf(placeholderRegex.FindStringSubmatch(match)[1], "%d", &n)
+		return links[n]
+	})
+}
+
+func applyEmphasis(text string) string {
+	text = boldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := boldPattern.FindStringSubmatch(match)
+		return "<strong>" + m[1] + m[2] + "</strong>"
+	})
+
+	// The delimiters are the characters right around the captured text;
+	// anything else the match covered is kept as written.
+	var out strings.Builder
+	last := 0
+	for _, loc := range italicPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2], loc[3]
+		if start < 0 {
+			start, end = loc[4], loc[5]
+		}
+		out.WriteString(text[last : start-1])
+		out.WriteString("<em>" + text[start:end] + "</em>")
+		last = end + 1
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
@@ -0,0 +1,130 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "should render paragraphs keeping line breaks",
+			input:    "Primeira linha\nsegunda linha\n\nOutro parágrafo",
+			expected: "<p>Primeira linha<br/>\nsegunda linha</p>\n<p>Outro parágrafo</p>\n",
+		},
+		{
+			name:     "should render headings and emphasis",
+			input:    "## Sobre a **vaga**\nTrabalho *remoto* com `Go`",
+			expected: "<h2>Sobre a <strong>vaga</strong></h2>\n<p>Trabalho <em>remoto</em> com <code>Go</code></p>\n",
+		},
+		{
+			name:     "should render bullet lists including the bullet character",
+			input:    "Requisitos:\n• Go\n- PostgreSQL",
+			expected: "<p>Requisitos:</p>\n<ul>\n<li>Go</li>\n<li>PostgreSQL</li>\n</ul>\n",
+		},
+		{
+			name:     "should render numbered lists",
+			input:    "1. Triagem\n2. Entrevista",
+			expected: "<ol>\n<li>Triagem</li>\n<li>Entrevista</li>\n</ol>\n",
+		},
+		{
+			name:     "should render safe links with rel attributes",
+			input:    "Veja [nosso site](https://example.com/a_b_c)",
+			expected: "<p>Veja <a href=\"https://example.com/a_b_c\" rel=\"nofollow noopener noreferrer\">nosso site</a></p>\n",
+		},
+		{
+			name:     "should drop javascript links keeping the label",
+			input:    "[clique](javascript:alert(1))",
+			expected: "<p>clique</p>\n",
+		},
+		{
+			name:     "should keep parentheses in link targets",
+			input:    "[Go](https://pt.wikipedia.org/wiki/Go_(linguagem))",
+			expected: "<p><a href=\"https://pt.wikipedia.org/wiki/Go_(linguagem)\" rel=\"nofollow noopener noreferrer\">Go</a></p>\n",
+		},
+		{
+			name:     "should leave a lone triple asterisk as text",
+			input:    "Salário: R$ ***",
+			expected: "<p>Salário: R$ ***</p>\n",
+		},
+		{
+			name:     "should leave a triple asterisk between words as text",
+			input:    "a *** b",
+			expected: "<p>a *** b</p>\n",
+		},
+		{
+			name:     "should render bold italic",
+			input:    "***Go***",
+			expected: "<p><strong><em>Go</em></strong></p>\n",
+		},
+		{
+			name:     "should escape raw html",
+			input:    "<script>alert('x')</script><img src=x onerror=alert(1)>",
+			expected: "<p>&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "should render fenced code verbatim",
+			input:    "```\n**not bold** <b>\n```",
+			expected: "<pre><code>**not bold** &lt;b&gt;</code></pre>\n",
+		},
+		{
+			name:     "should keep underscores inside words",
+			input:    "use snake_case_names",
+			expected: "<p>use snake_case_names</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToHTML(tt.input))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("should drop scripts with their content", func(t *testing.T) {
+		assert.Equal(t, "<p>ok</p>", Sanitize("<p>ok<script>alert(1)</script></p>"))
+	})
+
+	t.Run("should remove event handlers and unknown attributes", func(t *testing.T) {
+		assert.Equal(t, "<p>texto</p>", Sanitize(`<p onclick="alert(1)" style="color:red">texto</p>`))
+	})
+
+	t.Run("should unwrap tags outside the allowlist", func(t *testing.T) {
+		assert.Equal(t, "<p>a b</p>", Sanitize(`<div><p>a <span>b</span></p></div>`))
+	})
+
+	t.Run("should remove unsafe hrefs", func(t *testing.T) {
+		assert.Equal(t, "<a>x</a>", Sanitize(`<a href="javascript:alert(1)">x</a>`))
+		assert.Equal(t, "<a>x</a>", Sanitize(`<a href="//evil.example">x</a>`))
+		assert.Equal(t, `<a href="/jobs">x</a>`, Sanitize(`<a href="/jobs">x</a>`))
+	})
+}
+
+func TestToText(t *testing.T) {
+	t.Run("should strip formatting and link targets", func(t *testing.T) {
+		input := "## Sobre\nTrabalho **remoto** com [Go](https://go.dev).\n\n- PostgreSQL\n- Docker"
+		assert.Equal(t, "Sobre\n\nTrabalho remoto com Go.\n\nPostgreSQL\nDocker", ToText(input))
+	})
+
+	t.Run("should return plain text unchanged", func(t *testing.T) {
+		assert.Equal(t, "Desenvolvedor Go", ToText("Desenvolvedor Go"))
+	})
+
+	t.Run("should unescape entities", func(t *testing.T) {
+		assert.Equal(t, "P&D <time>", ToText("P&D <time>"))
+	})
+
+	t.Run("should keep the space between inline elements", func(t *testing.T) {
+		assert.Equal(t, "Go Rust", ToText("**Go** **Rust**"))
+	})
+
+	t.Run("should keep code block content as is", func(t *testing.T) {
+		assert.Equal(t, "go  test\n./...", ToText("```\ngo  test\n./...\n```"))
+	})
+}
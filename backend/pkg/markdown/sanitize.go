@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags lists the elements kept by Sanitize with the attributes each
+// may carry. Anything else is unwrapped to its text.
+var allowedTags = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Ul: nil, atom.Ol: nil, atom.Li: nil,
+	atom.Strong: nil, atom.Em: nil, atom.B: nil, atom.I: nil,
+	atom.Code: nil, atom.Pre: nil, atom.Blockquote: nil,
+	atom.A: {"href", "rel"},
+}
+
+// droppedTags are removed together with their content.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+	atom.Math: true, atom.Head: true, atom.Title: true, atom.Textarea: true,
+	atom.Select: true,
+}
+
+// Sanitize keeps only allowlisted elements and attributes of fragment, so
+// no scripts, styles or event handlers survive, and links only point to
+// http, https and mailto URLs or relative paths.
+func Sanitize(fragment string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return html.EscapeString(fragment)
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		for _, clean := range sanitizeNode(node) {
+			_ = html.Render(&out, clean)
+		}
+	}
+	return out.String()
+}
+
+// sanitizeNode returns what replaces n in the output: n itself cleaned, its
+// cleaned children when n is not allowed, or nothing.
+func sanitizeNode(n *html.Node) []*html.Node {
+	switch n.Type {
+	case html.TextNode:
+		return []*html.Node{{Type: html.TextNode, Data: n.Data}}
+	case html.ElementNode:
+	default:
+		return nil
+	}
+
+	if droppedTags[n.DataAtom] {
+		return nil
+	}
+
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		children = append(children, sanitizeNode(c)...)
+	}
+
+	attrs, allowed := allowedTags[n.DataAtom]
+	if !allowed {
+		return children
+	}
+
+	clean := &html.Node{Type: html.ElementNode, Data: n.Data, DataAtom: n.DataAtom}
+	for _, attr := range n.Attr {
+		if attr.Namespace != "" || !contains(attrs, attr.Key) {
+			continue
+		}
+		if attr.Key == "href" && !safeURL(attr.Val) {
+			continue
+		}
+		clean.Attr = append(clean.Attr, html.Attribute{Key: attr.Key, Val: attr.Val})
+	}
+	for _, child := range children {
+		clean.AppendChild(child)
+	}
+	return []*html.Node{clean}
+}
+
+func safeURL(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	case "":
+		// Relative links, but not protocol-relative ones to another host.
+		return !strings.HasPrefix(raw, "//") && u.Host == ""
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaces     = regexp.MustCompile(`\s+`)
+)
+
+// lineTags end a line of plain text and blockTags a paragraph.
+var (
+	lineTags  = map[atom.Atom]bool{atom.Br: true, atom.Li: true}
+	blockTags = map[atom.Atom]bool{
+		atom.P: true, atom.Hr: true, atom.Pre: true, atom.Blockquote: true, atom.Ul: true, atom.Ol: true,
+		atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	}
+)
+
+// ToText renders Markdown to plain text: the words a reader sees, without
+// markup, link targets or list markers. Paragraphs are separated by a blank
+// line and list items and line breaks by a newline.
+func ToText(source string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(ToHTML(source)), body)
+	if err != nil {
+		return source
+	}
+
+	var out strings.Builder
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		// Outside code blocks, newlines in text are layout, not content: like
+		// a browser, collapse whitespace, keeping the spaces between words.
+		if n.Type == html.TextNode {
+			if pre {
+				out.WriteString(n.Data)
+			} else {
+				out.WriteString(spaces.ReplaceAllString(n.Data, " "))
+			}
+		}
+		pre = pre || (n.Type == html.ElementNode && n.DataAtom == atom.Pre)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, pre)
+		}
+		if n.Type == html.ElementNode && lineTags[n.DataAtom] {
+			out.WriteString("\n")
+		}
+		if n.Type == html.ElementNode && blockTags[n.DataAtom] {
+			out.WriteString("\n\n")
+		}
+	}
+	for _, n := range nodes {
+		walk(n, false)
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text := strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
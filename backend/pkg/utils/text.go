@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
@@ -24,3 +25,49 @@ func NormalizeText(text string) string {
 	return strings.TrimSpace(result)
 }
 
+
+// NormalizeKey lowercases text, removes accents and collapses whitespace
+// while keeping punctuation, so names like "C++", "C#" and "Node.js" stay
+// distinct.
+func NormalizeKey(text string) string {
+	text = strings.ToLower(text)
+
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, _ := transform.String(t, text)
+
+	return strings.Join(strings.Fields(result), " ")
+}
+
+// MaxSlugLength bounds slugs so a numeric suffix still fits in 120 chars.
+const MaxSlugLength = 80
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slugify turns text into a URL-friendly slug: lowercase ASCII words without
+// accents joined by hyphens, cut at a word boundary to MaxSlugLength.
+func Slugify(text string) string {
+	slug := strings.ReplaceAll(NormalizeText(text), " ", "-")
+	if len(slug) <= MaxSlugLength {
+		return slug
+	}
+	slug = slug[:MaxSlugLength]
+	if i := strings.LastIndex(slug, "-"); i > 0 {
+		slug = slug[:i]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// IsSlug reports whether s is a well-formed slug as produced by Slugify.
+func IsSlug(s string) bool {
+	return len(s) <= MaxSlugLength && slugPattern.MatchString(s)
+}
+
+// UniqueSlug returns base, or base followed by the lowest number from 2 up,
+// that is not in used.
+func UniqueSlug(base string, used map[string]bool) string {
+	slug := base
+	for n := 2; used[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug
+}
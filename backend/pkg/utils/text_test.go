@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,3 +68,80 @@ func TestNormalizeText(t *testing.T) {
 	}
 }
 
+
+func TestNormalizeKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "should lowercase and trim",
+			input:    "  Golang ",
+			expected: "golang",
+		},
+		{
+			name:     "should remove accents and collapse spaces",
+			input:    "Gestão   de  Projetos",
+			expected: "gestao de projetos",
+		},
+		{
+			name:     "should keep punctuation",
+			input:    "C++",
+			expected: "c++",
+		},
+		{
+			name:     "should keep dots",
+			input:    "Node.js",
+			expected: "node.js",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeKey(tt.input))
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "should join normalized words with hyphens",
+			input:    "Desenvolvedor(a) Back-end Sênior – São Paulo",
+			expected: "desenvolvedor-a-back-end-senior-sao-paulo",
+		},
+		{
+			name:     "should be empty when nothing is left",
+			input:    "!!!",
+			expected: "",
+		},
+		{
+			name:     "should cut long titles at a word boundary",
+			input:    strings.Repeat("engenharia ", 10),
+			expected: strings.TrimSuffix(strings.Repeat("engenharia-", 7), "-"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug := Slugify(tt.input)
+			assert.Equal(t, tt.expected, slug)
+			assert.LessOrEqual(t, len(slug), MaxSlugLength)
+		})
+	}
+}
+
+func TestIsSlug(t *testing.T) {
+	t.Run("should accept slugs and reject anything else", func(t *testing.T) {
+		assert.True(t, IsSlug("backend-developer-2"))
+		assert.False(t, IsSlug("Backend Developer"))
+		assert.False(t, IsSlug("-backend"))
+		assert.False(t, IsSlug("backend--developer"))
+		assert.False(t, IsSlug(""))
+	})
+}
@@ -37,16 +37,19 @@ func (u TestUser) ToModel() *models.User {
 func CreateTestJob(recruiterID uuid.UUID, title, location string, jobType models.JobType) *models.Job {
 	salary := 5000.0
 	return &models.Job{
-		ID:          uuid.New(),
-		RecruiterID: recruiterID,
-		Title:       title,
-		Description: "Test job description for " + title,
-		Salary:      &salary,
-		Location:    location,
-		Type:        jobType,
-		Status:      models.JobStatusOpen,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:             uuid.New(),
+		RecruiterID:    recruiterID,
+		Title:          title,
+		Description:    "Test job description for " + title,
+		SalaryMin:      &salary,
+		SalaryMax:      &salary,
+		SalaryCurrency: models.DefaultSalaryCurrency,
+		SalaryPeriod:   models.SalaryPeriodMonth,
+		Location:       location,
+		Type:           jobType,
+		Status:         models.JobStatusOpen,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 }
 func CreateTestApplication(jobID, candidateID uuid.UUID) *models.Application {